@@ -0,0 +1,554 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// the `azurerm_linux_virtual_machine` and `azurerm_windows_virtual_machine` resources share the majority
+// of their schema & behaviour - which lives in this file
+
+func virtualMachineOSDiskSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"caching": {
+					Type:     schema.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(compute.CachingTypesNone),
+						string(compute.CachingTypesReadOnly),
+						string(compute.CachingTypesReadWrite),
+					}, false),
+				},
+
+				"storage_account_type": {
+					Type:     schema.TypeString,
+					Required: true,
+					// the API returns `Changing property 'osDisk.managedDisk.storageAccountType' is not allowed`
+					ForceNew: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(compute.StorageAccountTypesPremiumLRS),
+						string(compute.StorageAccountTypesStandardLRS),
+						string(compute.StorageAccountTypesStandardSSDLRS),
+					}, false),
+				},
+
+				"disk_size_gb": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntBetween(0, 4095),
+				},
+
+				"name": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ForceNew:     true,
+					Computed:     true,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+
+				"write_accelerator_enabled": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func expandVirtualMachineOSDisk(input []interface{}, osType compute.OperatingSystemTypes) *compute.OSDisk {
+	raw := input[0].(map[string]interface{})
+	disk := compute.OSDisk{
+		Caching: compute.CachingTypes(raw["caching"].(string)),
+		ManagedDisk: &compute.ManagedDiskParameters{
+			StorageAccountType: compute.StorageAccountTypes(raw["storage_account_type"].(string)),
+		},
+		WriteAcceleratorEnabled: utils.Bool(raw["write_accelerator_enabled"].(bool)),
+
+		// these have to be hard-coded so there's no point exposing them
+		CreateOption: compute.DiskCreateOptionTypesFromImage,
+		OsType:       osType,
+	}
+
+	if osDiskSize := raw["disk_size_gb"].(int); osDiskSize > 0 {
+		disk.DiskSizeGB = utils.Int32(int32(osDiskSize))
+	}
+
+	if name := raw["name"].(string); name != "" {
+		disk.Name = utils.String(name)
+	}
+
+	return &disk
+}
+
+func flattenVirtualMachineOSDisk(ctx context.Context, disksClient *compute.DisksClient, input *compute.OSDisk) ([]interface{}, error) {
+	if input == nil {
+		return []interface{}{}, nil
+	}
+
+	diskSizeGb := 0
+	if input.DiskSizeGB != nil && *input.DiskSizeGB != 0 {
+		diskSizeGb = int(*input.DiskSizeGB)
+	}
+
+	id := ""
+	storageAccountType := ""
+	if input.ManagedDisk != nil {
+		storageAccountType = string(input.ManagedDisk.StorageAccountType)
+
+		if input.ManagedDisk.ID != nil {
+			id = *input.ManagedDisk.ID
+
+			parsedId, err := parseAzureResourceID(id)
+			if err != nil {
+				return nil, err
+			}
+
+			diskName := parsedId.Path["disks"]
+			disk, err := disksClient.Get(ctx, parsedId.ResourceGroup, diskName)
+			if err != nil {
+				return nil, fmt.Errorf("Error retrieving Managed OS Disk %q (Resource Group %q): %+v", diskName, parsedId.ResourceGroup, err)
+			}
+
+			// whilst this is available as `input.ManagedDisk.StorageAccountType` it's not returned from the API when
+			// the Virtual Machine is Deallocated - so we check the Disk itself to be sure
+			if sku := disk.Sku; sku != nil {
+				storageAccountType = string(sku.Name)
+			}
+
+			// the Disk Size is only returned from the VM API once it's been resized, so we check the Disk too
+			if props := disk.DiskProperties; props != nil && props.DiskSizeGB != nil && diskSizeGb == 0 {
+				diskSizeGb = int(*props.DiskSizeGB)
+			}
+		}
+	}
+
+	name := ""
+	if input.Name != nil {
+		name = *input.Name
+	}
+
+	writeAcceleratorEnabled := false
+	if input.WriteAcceleratorEnabled != nil {
+		writeAcceleratorEnabled = *input.WriteAcceleratorEnabled
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"caching":                   string(input.Caching),
+			"disk_size_gb":              diskSizeGb,
+			"id":                        id,
+			"name":                      name,
+			"storage_account_type":      storageAccountType,
+			"write_accelerator_enabled": writeAcceleratorEnabled,
+		},
+	}, nil
+}
+
+func virtualMachineSourceImageReferenceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		ForceNew:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"source_image_id"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"publisher": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+				"offer": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+				"sku": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+				"version": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+			},
+		},
+	}
+}
+
+func expandVirtualMachineSourceImageReference(d *schema.ResourceData) (*compute.ImageReference, error) {
+	if v, ok := d.GetOk("source_image_id"); ok {
+		return &compute.ImageReference{
+			ID: utils.String(v.(string)),
+		}, nil
+	}
+
+	references := d.Get("source_image_reference").([]interface{})
+	if len(references) == 0 {
+		return nil, fmt.Errorf("Either a `source_image_id` or a `source_image_reference` block must be specified!")
+	}
+
+	raw := references[0].(map[string]interface{})
+	return &compute.ImageReference{
+		Publisher: utils.String(raw["publisher"].(string)),
+		Offer:     utils.String(raw["offer"].(string)),
+		Sku:       utils.String(raw["sku"].(string)),
+		Version:   utils.String(raw["version"].(string)),
+	}, nil
+}
+
+func flattenVirtualMachineSourceImageReference(input *compute.ImageReference) []interface{} {
+	// since the image id is pulled out as a separate field, if that's set we should return an empty block here
+	if input == nil || input.ID != nil {
+		return []interface{}{}
+	}
+
+	var publisher, offer, sku, version string
+
+	if input.Publisher != nil {
+		publisher = *input.Publisher
+	}
+	if input.Offer != nil {
+		offer = *input.Offer
+	}
+	if input.Sku != nil {
+		sku = *input.Sku
+	}
+	if input.Version != nil {
+		version = *input.Version
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"publisher": publisher,
+			"offer":     offer,
+			"sku":       sku,
+			"version":   version,
+		},
+	}
+}
+
+func virtualMachineBootDiagnosticsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"storage_account_uri": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+			},
+		},
+	}
+}
+
+func expandVirtualMachineBootDiagnostics(input []interface{}) *compute.DiagnosticsProfile {
+	if len(input) == 0 {
+		return &compute.DiagnosticsProfile{
+			BootDiagnostics: &compute.BootDiagnostics{
+				Enabled: utils.Bool(false),
+			},
+		}
+	}
+
+	raw := input[0].(map[string]interface{})
+	return &compute.DiagnosticsProfile{
+		BootDiagnostics: &compute.BootDiagnostics{
+			Enabled:    utils.Bool(true),
+			StorageURI: utils.String(raw["storage_account_uri"].(string)),
+		},
+	}
+}
+
+func flattenVirtualMachineBootDiagnostics(input *compute.DiagnosticsProfile) []interface{} {
+	if input == nil || input.BootDiagnostics == nil || input.BootDiagnostics.Enabled == nil || !*input.BootDiagnostics.Enabled {
+		return []interface{}{}
+	}
+
+	storageAccountUri := ""
+	if input.BootDiagnostics.StorageURI != nil {
+		storageAccountUri = *input.BootDiagnostics.StorageURI
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"storage_account_uri": storageAccountUri,
+		},
+	}
+}
+
+func virtualMachineIdentitySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:     schema.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(compute.ResourceIdentityTypeSystemAssigned),
+						string(compute.ResourceIdentityTypeUserAssigned),
+						string(compute.ResourceIdentityTypeSystemAssignedUserAssigned),
+					}, false),
+				},
+
+				"identity_ids": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: azure.ValidateResourceID,
+					},
+				},
+
+				"principal_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func expandVirtualMachineIdentity(input []interface{}) (*compute.VirtualMachineIdentity, error) {
+	if len(input) == 0 {
+		return &compute.VirtualMachineIdentity{
+			Type: compute.ResourceIdentityTypeNone,
+		}, nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	identity := compute.VirtualMachineIdentity{
+		Type: compute.ResourceIdentityType(raw["type"].(string)),
+	}
+
+	identityIdsRaw := raw["identity_ids"].([]interface{})
+	identityIds := make(map[string]*compute.VirtualMachineIdentityUserAssignedIdentitiesValue)
+	for _, v := range identityIdsRaw {
+		identityIds[v.(string)] = &compute.VirtualMachineIdentityUserAssignedIdentitiesValue{}
+	}
+
+	if len(identityIds) > 0 {
+		if identity.Type != compute.ResourceIdentityTypeUserAssigned && identity.Type != compute.ResourceIdentityTypeSystemAssignedUserAssigned {
+			return nil, fmt.Errorf("`identity_ids` can only be specified when `type` includes `UserAssigned`")
+		}
+
+		identity.UserAssignedIdentities = identityIds
+	}
+
+	return &identity, nil
+}
+
+func flattenVirtualMachineIdentity(input *compute.VirtualMachineIdentity) []interface{} {
+	if input == nil || input.Type == compute.ResourceIdentityTypeNone {
+		return []interface{}{}
+	}
+
+	identityIds := make([]string, 0)
+	if input.UserAssignedIdentities != nil {
+		for key := range input.UserAssignedIdentities {
+			identityIds = append(identityIds, key)
+		}
+	}
+	// the identities are returned as a map, so are sorted to keep the ordering stable
+	sort.Strings(identityIds)
+
+	principalId := ""
+	if input.PrincipalID != nil {
+		principalId = *input.PrincipalID
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type":         string(input.Type),
+			"identity_ids": identityIds,
+			"principal_id": principalId,
+		},
+	}
+}
+
+func expandVirtualMachineNetworkInterfaceIDs(input []interface{}) []compute.NetworkInterfaceReference {
+	output := make([]compute.NetworkInterfaceReference, 0)
+
+	for i, v := range input {
+		output = append(output, compute.NetworkInterfaceReference{
+			ID: utils.String(v.(string)),
+			NetworkInterfaceReferenceProperties: &compute.NetworkInterfaceReferenceProperties{
+				// the first Network Interface is always the Primary
+				Primary: utils.Bool(i == 0),
+			},
+		})
+	}
+
+	return output
+}
+
+func flattenVirtualMachineNetworkInterfaceIDs(input *[]compute.NetworkInterfaceReference) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make([]interface{}, 0)
+
+	for _, v := range *input {
+		if v.ID == nil {
+			continue
+		}
+
+		output = append(output, *v.ID)
+	}
+
+	return output
+}
+
+// virtualMachineShouldBeDeallocated returns whether changes to the Virtual Machine require it to be
+// deallocated before they can be applied, which is the case when resizing the VM or its OS Disk
+func virtualMachineShouldBeDeallocated(d *schema.ResourceData) bool {
+	return d.HasChange("size") || d.HasChange("os_disk.0.disk_size_gb")
+}
+
+// updateVirtualMachine applies the specified update to the Virtual Machine - deallocating it before
+// the update when required and then returning it to the Power State it was in beforehand
+func updateVirtualMachine(ctx context.Context, client *compute.VirtualMachinesClient, resourceGroup, name string, update compute.VirtualMachineUpdate, shouldDeallocate bool) (err error) {
+	if shouldDeallocate {
+		powerState, stateErr := getVirtualMachinePowerState(ctx, client, resourceGroup, name)
+		if stateErr != nil {
+			return stateErr
+		}
+
+		if powerState != virtualMachinePowerStateDeallocated {
+			log.Printf("[DEBUG] Deallocating Virtual Machine %q (Resource Group %q)..", name, resourceGroup)
+			future, deallocateErr := client.Deallocate(ctx, resourceGroup, name)
+			if deallocateErr != nil {
+				return fmt.Errorf("Error deallocating Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, deallocateErr)
+			}
+
+			// the original Power State is restored even if the update fails, so the VM isn't left deallocated
+			defer func() {
+				if restoreErr := restoreVirtualMachinePowerState(ctx, client, resourceGroup, name, powerState); restoreErr != nil {
+					if err != nil {
+						err = fmt.Errorf("%+v\n\nAdditionally, the Power State of the Virtual Machine couldn't be restored: %+v", err, restoreErr)
+					} else {
+						err = restoreErr
+					}
+				}
+			}()
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("Error waiting for deallocation of Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+			}
+			log.Printf("[DEBUG] Deallocated Virtual Machine %q (Resource Group %q)..", name, resourceGroup)
+		}
+	}
+
+	log.Printf("[DEBUG] Updating Virtual Machine %q (Resource Group %q)..", name, resourceGroup)
+	future, err := client.Update(ctx, resourceGroup, name, update)
+	if err != nil {
+		return fmt.Errorf("Error updating Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for update of Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	log.Printf("[DEBUG] Updated Virtual Machine %q (Resource Group %q).", name, resourceGroup)
+
+	return nil
+}
+
+// restoreVirtualMachinePowerState returns a deallocated Virtual Machine to the Power State it was in before it was deallocated
+func restoreVirtualMachinePowerState(ctx context.Context, client *compute.VirtualMachinesClient, resourceGroup, name, powerState string) error {
+	switch powerState {
+	case virtualMachinePowerStateDeallocated:
+		return nil
+
+	case virtualMachinePowerStateStopped:
+		// a deallocated Virtual Machine has to be started before it can be stopped (rather than deallocated)
+		if err := setVirtualMachinePowerState(ctx, client, resourceGroup, name, virtualMachinePowerStateRunning); err != nil {
+			return err
+		}
+		return setVirtualMachinePowerState(ctx, client, resourceGroup, name, virtualMachinePowerStateStopped)
+	}
+
+	return setVirtualMachinePowerState(ctx, client, resourceGroup, name, virtualMachinePowerStateRunning)
+}
+
+// deleteVirtualMachine deletes the Virtual Machine and its OS Disk, which is managed by these resources
+func deleteVirtualMachine(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["virtualMachines"]
+
+	azureRMLockByName(name, virtualMachineResourceName)
+	defer azureRMUnlockByName(name, virtualMachineResourceName)
+
+	existing, err := client.Get(ctx, resourceGroup, name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(existing.Response) {
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	log.Printf("[DEBUG] Deleting Virtual Machine %q (Resource Group %q)..", name, resourceGroup)
+	future, err := client.Delete(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error deleting Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for deletion of Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	log.Printf("[DEBUG] Deleted Virtual Machine %q (Resource Group %q).", name, resourceGroup)
+
+	// the OS Disk is created with the Virtual Machine, so we remove it too
+	if props := existing.VirtualMachineProperties; props != nil && props.StorageProfile != nil && props.StorageProfile.OsDisk != nil {
+		if disk := props.StorageProfile.OsDisk.ManagedDisk; disk != nil && disk.ID != nil {
+			log.Printf("[DEBUG] Deleting OS Disk %q from Virtual Machine %q (Resource Group %q)..", *disk.ID, name, resourceGroup)
+			if err := resourceArmVirtualMachineDeleteManagedDisk(disk, meta); err != nil {
+				return fmt.Errorf("Error deleting OS Disk %q for Virtual Machine %q (Resource Group %q): %+v", *disk.ID, name, resourceGroup, err)
+			}
+			log.Printf("[DEBUG] Deleted OS Disk %q from Virtual Machine %q (Resource Group %q).", *disk.ID, name, resourceGroup)
+		}
+	}
+
+	return nil
+}
@@ -147,19 +147,22 @@ type ArmClient struct {
 	batchPoolClient        batch.PoolClient
 
 	// Compute
-	availSetClient             compute.AvailabilitySetsClient
-	diskClient                 compute.DisksClient
-	imageClient                compute.ImagesClient
-	galleriesClient            compute.GalleriesClient
-	galleryImagesClient        compute.GalleryImagesClient
-	galleryImageVersionsClient compute.GalleryImageVersionsClient
-	snapshotsClient            compute.SnapshotsClient
-	usageOpsClient             compute.UsageClient
-	vmExtensionImageClient     compute.VirtualMachineExtensionImagesClient
-	vmExtensionClient          compute.VirtualMachineExtensionsClient
-	vmScaleSetClient           compute.VirtualMachineScaleSetsClient
-	vmImageClient              compute.VirtualMachineImagesClient
-	vmClient                   compute.VirtualMachinesClient
+	availSetClient                 compute.AvailabilitySetsClient
+	diskClient                     compute.DisksClient
+	imageClient                    compute.ImagesClient
+	galleriesClient                compute.GalleriesClient
+	galleryImagesClient            compute.GalleryImagesClient
+	galleryImageVersionsClient     compute.GalleryImageVersionsClient
+	proximityPlacementGroupsClient compute.ProximityPlacementGroupsClient
+	snapshotsClient                compute.SnapshotsClient
+	usageOpsClient                 compute.UsageClient
+	vmExtensionImageClient         compute.VirtualMachineExtensionImagesClient
+	vmExtensionClient              compute.VirtualMachineExtensionsClient
+	vmScaleSetClient               compute.VirtualMachineScaleSetsClient
+	vmScaleSetExtensionsClient     compute.VirtualMachineScaleSetExtensionsClient
+	vmScaleSetVMsClient            compute.VirtualMachineScaleSetVMsClient
+	vmImageClient                  compute.VirtualMachineImagesClient
+	vmClient                       compute.VirtualMachinesClient
 
 	// Databases
 	mariadbDatabasesClient                   mariadb.DatabasesClient
@@ -177,9 +180,11 @@ type ArmClient struct {
 	postgresqlVirtualNetworkRulesClient      postgresql.VirtualNetworkRulesClient
 	sqlDatabasesClient                       sql.DatabasesClient
 	sqlDatabaseThreatDetectionPoliciesClient sql.DatabaseThreatDetectionPoliciesClient
+	sqlDatabaseBlobAuditingPoliciesClient    sql.DatabaseBlobAuditingPoliciesClient
 	sqlElasticPoolsClient                    sql.ElasticPoolsClient
 	// Client for the new 2017-10-01-preview SQL API which implements vCore, DTU, and Azure data standards
 	msSqlElasticPoolsClient              MsSql.ElasticPoolsClient
+	sqlFailoverGroupsClient              sql.FailoverGroupsClient
 	sqlFirewallRulesClient               sql.FirewallRulesClient
 	sqlServersClient                     sql.ServersClient
 	sqlServerAzureADAdministratorsClient sql.ServerAzureADAdministratorsClient
@@ -208,31 +213,36 @@ type ArmClient struct {
 	monitorMetricAlertsClient               insights.MetricAlertsClient
 
 	// Networking
-	applicationGatewayClient        network.ApplicationGatewaysClient
-	applicationSecurityGroupsClient network.ApplicationSecurityGroupsClient
-	azureFirewallsClient            network.AzureFirewallsClient
-	connectionMonitorsClient        network.ConnectionMonitorsClient
-	ddosProtectionPlanClient        network.DdosProtectionPlansClient
-	expressRouteAuthsClient         network.ExpressRouteCircuitAuthorizationsClient
-	expressRouteCircuitClient       network.ExpressRouteCircuitsClient
-	expressRoutePeeringsClient      network.ExpressRouteCircuitPeeringsClient
-	ifaceClient                     network.InterfacesClient
-	loadBalancerClient              network.LoadBalancersClient
-	localNetConnClient              network.LocalNetworkGatewaysClient
-	netProfileClient                network.ProfilesClient
-	packetCapturesClient            network.PacketCapturesClient
-	publicIPClient                  network.PublicIPAddressesClient
-	publicIPPrefixClient            network.PublicIPPrefixesClient
-	routesClient                    network.RoutesClient
-	routeTablesClient               network.RouteTablesClient
-	secGroupClient                  network.SecurityGroupsClient
-	secRuleClient                   network.SecurityRulesClient
-	subnetClient                    network.SubnetsClient
-	vnetGatewayConnectionsClient    network.VirtualNetworkGatewayConnectionsClient
-	vnetGatewayClient               network.VirtualNetworkGatewaysClient
-	vnetClient                      network.VirtualNetworksClient
-	vnetPeeringsClient              network.VirtualNetworkPeeringsClient
-	watcherClient                   network.WatchersClient
+	applicationGatewayClient           network.ApplicationGatewaysClient
+	applicationSecurityGroupsClient    network.ApplicationSecurityGroupsClient
+	azureFirewallsClient               network.AzureFirewallsClient
+	connectionMonitorsClient           network.ConnectionMonitorsClient
+	ddosProtectionPlanClient           network.DdosProtectionPlansClient
+	expressRouteAuthsClient            network.ExpressRouteCircuitAuthorizationsClient
+	expressRouteCircuitClient          network.ExpressRouteCircuitsClient
+	expressRoutePeeringsClient         network.ExpressRouteCircuitPeeringsClient
+	ifaceClient                        network.InterfacesClient
+	loadBalancerClient                 network.LoadBalancersClient
+	localNetConnClient                 network.LocalNetworkGatewaysClient
+	netProfileClient                   network.ProfilesClient
+	packetCapturesClient               network.PacketCapturesClient
+	publicIPClient                     network.PublicIPAddressesClient
+	publicIPPrefixClient               network.PublicIPPrefixesClient
+	routesClient                       network.RoutesClient
+	routeTablesClient                  network.RouteTablesClient
+	secGroupClient                     network.SecurityGroupsClient
+	secRuleClient                      network.SecurityRulesClient
+	subnetClient                       network.SubnetsClient
+	vnetGatewayConnectionsClient       network.VirtualNetworkGatewayConnectionsClient
+	vnetGatewayClient                  network.VirtualNetworkGatewaysClient
+	vnetClient                         network.VirtualNetworksClient
+	vnetPeeringsClient                 network.VirtualNetworkPeeringsClient
+	virtualHubClient                   network.VirtualHubsClient
+	virtualWanClient                   network.VirtualWansClient
+	vpnGatewayClient                   network.VpnGatewaysClient
+	vpnSitesClient                     network.VpnSitesClient
+	watcherClient                      network.WatchersClient
+	webApplicationFirewallPolicyClient network.WebApplicationFirewallPoliciesClient
 
 	// Resources
 	managementLocksClient locks.ManagementLocksClient
@@ -461,6 +471,10 @@ func (c *ArmClient) registerComputeClients(endpoint, subscriptionId string, auth
 	c.configureClient(&imagesClient.Client, auth)
 	c.imageClient = imagesClient
 
+	proximityPlacementGroupsClient := compute.NewProximityPlacementGroupsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&proximityPlacementGroupsClient.Client, auth)
+	c.proximityPlacementGroupsClient = proximityPlacementGroupsClient
+
 	snapshotsClient := compute.NewSnapshotsClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&snapshotsClient.Client, auth)
 	c.snapshotsClient = snapshotsClient
@@ -485,6 +499,14 @@ func (c *ArmClient) registerComputeClients(endpoint, subscriptionId string, auth
 	c.configureClient(&scaleSetsClient.Client, auth)
 	c.vmScaleSetClient = scaleSetsClient
 
+	scaleSetVMsClient := compute.NewVirtualMachineScaleSetVMsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&scaleSetVMsClient.Client, auth)
+	c.vmScaleSetVMsClient = scaleSetVMsClient
+
+	scaleSetExtensionsClient := compute.NewVirtualMachineScaleSetExtensionsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&scaleSetExtensionsClient.Client, auth)
+	c.vmScaleSetExtensionsClient = scaleSetExtensionsClient
+
 	virtualMachinesClient := compute.NewVirtualMachinesClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&virtualMachinesClient.Client, auth)
 	c.vmClient = virtualMachinesClient
@@ -569,6 +591,14 @@ func (c *ArmClient) registerDatabases(endpoint, subscriptionId string, auth auto
 	sqlDTDPClient.SkipResourceProviderRegistration = c.skipProviderRegistration
 	c.sqlDatabaseThreatDetectionPoliciesClient = sqlDTDPClient
 
+	sqlDBAPClient := sql.NewDatabaseBlobAuditingPoliciesClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&sqlDBAPClient.Client, auth)
+	c.sqlDatabaseBlobAuditingPoliciesClient = sqlDBAPClient
+
+	sqlFGClient := sql.NewFailoverGroupsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&sqlFGClient.Client, auth)
+	c.sqlFailoverGroupsClient = sqlFGClient
+
 	sqlFWClient := sql.NewFirewallRulesClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&sqlFWClient.Client, auth)
 	c.sqlFirewallRulesClient = sqlFWClient
@@ -757,9 +787,29 @@ func (c *ArmClient) registerNetworkingClients(endpoint, subscriptionId string, a
 	c.configureClient(&subnetsClient.Client, auth)
 	c.subnetClient = subnetsClient
 
+	virtualHubsClient := network.NewVirtualHubsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&virtualHubsClient.Client, auth)
+	c.virtualHubClient = virtualHubsClient
+
+	virtualWansClient := network.NewVirtualWansClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&virtualWansClient.Client, auth)
+	c.virtualWanClient = virtualWansClient
+
+	vpnGatewaysClient := network.NewVpnGatewaysClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&vpnGatewaysClient.Client, auth)
+	c.vpnGatewayClient = vpnGatewaysClient
+
+	vpnSitesClient := network.NewVpnSitesClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&vpnSitesClient.Client, auth)
+	c.vpnSitesClient = vpnSitesClient
+
 	watchersClient := network.NewWatchersClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&watchersClient.Client, auth)
 	c.watcherClient = watchersClient
+
+	webApplicationFirewallPolicyClient := network.NewWebApplicationFirewallPoliciesClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&webApplicationFirewallPolicyClient.Client, auth)
+	c.webApplicationFirewallPolicyClient = webApplicationFirewallPolicyClient
 }
 
 func (c *ArmClient) registerResourcesClients(endpoint, subscriptionId string, auth autorest.Authorizer) {
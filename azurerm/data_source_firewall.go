@@ -55,6 +55,11 @@ func dataSourceArmFirewall() *schema.Resource {
 				},
 			},
 
+			"threat_intel_mode": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"tags": tagsForDataSourceSchema(),
 		},
 	}
@@ -91,6 +96,8 @@ func dataSourceArmFirewallRead(d *schema.ResourceData, meta interface{}) error {
 		if err := d.Set("ip_configuration", ipConfigs); err != nil {
 			return fmt.Errorf("Error setting `ip_configuration`: %+v", err)
 		}
+
+		d.Set("threat_intel_mode", string(props.ThreatIntelMode))
 	}
 
 	flattenAndSetTags(d, read.Tags)
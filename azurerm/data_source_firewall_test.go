@@ -23,6 +23,7 @@ func TestAccDataSourceAzureRMFirewall_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(dataSourceName, "ip_configuration.0.name", "configuration"),
 					resource.TestCheckResourceAttrSet(dataSourceName, "ip_configuration.0.private_ip_address"),
+					resource.TestCheckResourceAttr(dataSourceName, "threat_intel_mode", "Alert"),
 				),
 			},
 		},
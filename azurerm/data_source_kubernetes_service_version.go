@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
-	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
 func dataSourceArmKubernetesServiceVersions() *schema.Resource {
@@ -43,12 +42,9 @@ func dataSourceArmKubernetesServiceVersionsRead(d *schema.ResourceData, meta int
 
 	location := azure.NormalizeLocation(d.Get("location").(string))
 
-	listResp, err := client.ListOrchestrators(ctx, location, "managedClusters")
+	id, kubeVersions, err := listKubernetesServiceVersions(ctx, client, location)
 	if err != nil {
-		if utils.ResponseWasNotFound(listResp.Response) {
-			return fmt.Errorf("Error: No Kubernetes Service versions found for location %q", location)
-		}
-		return fmt.Errorf("Error retrieving Kubernetes Versions in %q: %+v", location, err)
+		return err
 	}
 
 	lv, err := version.NewVersion("0.0.0")
@@ -59,41 +55,25 @@ func dataSourceArmKubernetesServiceVersionsRead(d *schema.ResourceData, meta int
 	var versions []string
 	versionPrefix := d.Get("version_prefix").(string)
 
-	if props := listResp.OrchestratorVersionProfileProperties; props != nil {
-		if orchestrators := props.Orchestrators; orchestrators != nil {
-			for _, rawV := range *orchestrators {
-				if rawV.OrchestratorType == nil || rawV.OrchestratorVersion == nil {
-					continue
-				}
-
-				orchestratorType := *rawV.OrchestratorType
-				kubeVersion := *rawV.OrchestratorVersion
-
-				if !strings.EqualFold(orchestratorType, "Kubernetes") {
-					log.Printf("[DEBUG] Orchestrator %q was not Kubernetes", orchestratorType)
-					continue
-				}
-
-				if versionPrefix != "" && !strings.HasPrefix(kubeVersion, versionPrefix) {
-					log.Printf("[DEBUG] Version %q doesn't match the prefix %q", kubeVersion, versionPrefix)
-					continue
-				}
-
-				versions = append(versions, kubeVersion)
-				v, err := version.NewVersion(kubeVersion)
-				if err != nil {
-					log.Printf("[WARN] Cannot parse orchestrator version %q - skipping: %s", kubeVersion, err)
-					continue
-				}
-
-				if v.GreaterThan(lv) {
-					lv = v
-				}
-			}
+	for _, kubeVersion := range kubeVersions {
+		if versionPrefix != "" && !strings.HasPrefix(kubeVersion, versionPrefix) {
+			log.Printf("[DEBUG] Version %q doesn't match the prefix %q", kubeVersion, versionPrefix)
+			continue
+		}
+
+		versions = append(versions, kubeVersion)
+		v, err := version.NewVersion(kubeVersion)
+		if err != nil {
+			log.Printf("[WARN] Cannot parse orchestrator version %q - skipping: %s", kubeVersion, err)
+			continue
+		}
+
+		if v.GreaterThan(lv) {
+			lv = v
 		}
 	}
 
-	d.SetId(*listResp.ID)
+	d.SetId(*id)
 	d.Set("versions", versions)
 	d.Set("latest_version", lv.Original())
 
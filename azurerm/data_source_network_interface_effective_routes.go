@@ -0,0 +1,133 @@
+package azurerm
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmNetworkInterfaceEffectiveRoutes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmNetworkInterfaceEffectiveRoutesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupNameForDataSource(),
+
+			"routes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"address_prefixes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"next_hop_ip_addresses": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"next_hop_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmNetworkInterfaceEffectiveRoutesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).ifaceClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	name := d.Get("name").(string)
+
+	iface, err := client.Get(ctx, resourceGroup, name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(iface.Response) {
+			return fmt.Errorf("Error: Network Interface %q (Resource Group %q) was not found", name, resourceGroup)
+		}
+		return fmt.Errorf("Error retrieving Network Interface %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	if iface.ID == nil {
+		return fmt.Errorf("Error retrieving Network Interface %q (Resource Group %q): `id` was nil", name, resourceGroup)
+	}
+
+	future, err := client.GetEffectiveRouteTable(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Effective Routes for Network Interface %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for Effective Routes for Network Interface %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	result, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving result of Effective Routes for Network Interface %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.SetId(*iface.ID)
+
+	if err := d.Set("routes", flattenArmNetworkInterfaceEffectiveRoutes(result.Value)); err != nil {
+		return fmt.Errorf("Error setting `routes`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenArmNetworkInterfaceEffectiveRoutes(input *[]network.EffectiveRoute) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		name := ""
+		if item.Name != nil {
+			name = *item.Name
+		}
+
+		results = append(results, map[string]interface{}{
+			"name":                  name,
+			"source":                string(item.Source),
+			"state":                 string(item.State),
+			"address_prefixes":      utils.FlattenStringSlice(item.AddressPrefix),
+			"next_hop_ip_addresses": utils.FlattenStringSlice(item.NextHopIPAddress),
+			"next_hop_type":         string(item.NextHopType),
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,198 @@
+package azurerm
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmNetworkInterfaceEffectiveSecurityRules() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmNetworkInterfaceEffectiveSecurityRulesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupNameForDataSource(),
+
+			"security_rules": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_security_group_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"access": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"priority": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"direction": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"source_port_ranges": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"destination_port_ranges": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"source_address_prefixes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"destination_address_prefixes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"expanded_source_address_prefixes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"expanded_destination_address_prefixes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmNetworkInterfaceEffectiveSecurityRulesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).ifaceClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	name := d.Get("name").(string)
+
+	iface, err := client.Get(ctx, resourceGroup, name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(iface.Response) {
+			return fmt.Errorf("Error: Network Interface %q (Resource Group %q) was not found", name, resourceGroup)
+		}
+		return fmt.Errorf("Error retrieving Network Interface %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	if iface.ID == nil {
+		return fmt.Errorf("Error retrieving Network Interface %q (Resource Group %q): `id` was nil", name, resourceGroup)
+	}
+
+	future, err := client.ListEffectiveNetworkSecurityGroups(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Effective Security Rules for Network Interface %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for Effective Security Rules for Network Interface %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	result, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving result of Effective Security Rules for Network Interface %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.SetId(*iface.ID)
+
+	if err := d.Set("security_rules", flattenArmNetworkInterfaceEffectiveSecurityRules(result.Value)); err != nil {
+		return fmt.Errorf("Error setting `security_rules`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenArmNetworkInterfaceEffectiveSecurityRules(input *[]network.EffectiveNetworkSecurityGroup) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, group := range *input {
+		if group.EffectiveSecurityRules == nil {
+			continue
+		}
+
+		networkSecurityGroupId := ""
+		if group.NetworkSecurityGroup != nil && group.NetworkSecurityGroup.ID != nil {
+			networkSecurityGroupId = *group.NetworkSecurityGroup.ID
+		}
+
+		for _, rule := range *group.EffectiveSecurityRules {
+			name := ""
+			if rule.Name != nil {
+				name = *rule.Name
+			}
+
+			priority := 0
+			if rule.Priority != nil {
+				priority = int(*rule.Priority)
+			}
+
+			results = append(results, map[string]interface{}{
+				"network_security_group_id":             networkSecurityGroupId,
+				"name":                                  name,
+				"protocol":                              string(rule.Protocol),
+				"access":                                string(rule.Access),
+				"priority":                              priority,
+				"direction":                             string(rule.Direction),
+				"source_port_ranges":                    flattenArmNetworkInterfaceEffectiveSecurityRuleValues(rule.SourcePortRange, rule.SourcePortRanges),
+				"destination_port_ranges":               flattenArmNetworkInterfaceEffectiveSecurityRuleValues(rule.DestinationPortRange, rule.DestinationPortRanges),
+				"source_address_prefixes":               flattenArmNetworkInterfaceEffectiveSecurityRuleValues(rule.SourceAddressPrefix, rule.SourceAddressPrefixes),
+				"destination_address_prefixes":          flattenArmNetworkInterfaceEffectiveSecurityRuleValues(rule.DestinationAddressPrefix, rule.DestinationAddressPrefixes),
+				"expanded_source_address_prefixes":      utils.FlattenStringSlice(rule.ExpandedSourceAddressPrefix),
+				"expanded_destination_address_prefixes": utils.FlattenStringSlice(rule.ExpandedDestinationAddressPrefix),
+			})
+		}
+	}
+
+	return results
+}
+
+// the API returns either a single value or a list of values for ports & prefixes, so we combine them here
+func flattenArmNetworkInterfaceEffectiveSecurityRuleValues(single *string, multiple *[]string) []interface{} {
+	results := utils.FlattenStringSlice(multiple)
+	if single != nil && *single != "" {
+		results = append([]interface{}{*single}, results...)
+	}
+	return results
+}
@@ -0,0 +1,47 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+)
+
+func TestAccDataSourceNetworkInterfaceEffectiveSecurityRules_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_network_interface_effective_security_rules.test"
+	ri := tf.AccRandTimeInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceNetworkInterfaceEffectiveSecurityRules_basic(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "security_rules.#"),
+					resource.TestCheckResourceAttr(dataSourceName, "security_rules.0.name", "securityRules/allow-ssh"),
+					resource.TestCheckResourceAttr(dataSourceName, "security_rules.0.access", "Allow"),
+					resource.TestCheckResourceAttr(dataSourceName, "security_rules.0.priority", "100"),
+					resource.TestCheckResourceAttr(dataSourceName, "security_rules.0.destination_port_ranges.0", "22-22"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceNetworkInterfaceEffectiveSecurityRules_basic(rInt int, location string) string {
+	template := testAccDataSourceNetworkInterfaceEffective_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_network_interface_effective_security_rules" "test" {
+  name                = "${azurerm_network_interface.test.name}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  # the effective security rules are only available once the NIC is attached to a running VM
+  depends_on = ["azurerm_virtual_machine.test"]
+}
+`, template)
+}
@@ -3,6 +3,7 @@ package azurerm
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -66,6 +67,11 @@ func dataSourceArmSharedImageVersion() *schema.Resource {
 				Computed: true,
 			},
 
+			"end_of_life_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"tags": tagsForDataSourceSchema(),
 		},
 	}
@@ -105,6 +111,12 @@ func dataSourceArmSharedImageVersionRead(d *schema.ResourceData, meta interface{
 		if profile := props.PublishingProfile; profile != nil {
 			d.Set("exclude_from_latest", profile.ExcludeFromLatest)
 
+			endOfLifeDate := ""
+			if profile.EndOfLifeDate != nil {
+				endOfLifeDate = profile.EndOfLifeDate.Format(time.RFC3339)
+			}
+			d.Set("end_of_life_date", endOfLifeDate)
+
 			flattenedRegions := flattenSharedImageVersionDataSourceTargetRegions(profile.TargetRegions)
 			if err := d.Set("target_region", flattenedRegions); err != nil {
 				return fmt.Errorf("Error setting `target_region`: %+v", err)
@@ -0,0 +1,266 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmVirtualMachineScaleSet() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmVirtualMachineScaleSetRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupNameForDataSource(),
+
+			"location": azure.SchemaLocationForDataSource(),
+
+			"zones": azure.SchemaZonesComputed(),
+
+			"sku": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"tier": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"capacity": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"upgrade_policy_mode": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"instances": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"computer_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"latest_model_applied": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"power_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"private_ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"private_ip_addresses": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"virtual_machine_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsForDataSourceSchema(),
+		},
+	}
+}
+
+func dataSourceArmVirtualMachineScaleSetRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmScaleSetClient
+	vmsClient := meta.(*ArmClient).vmScaleSetVMsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	name := d.Get("name").(string)
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("Error: Virtual Machine Scale Set %q (Resource Group %q) was not found", name, resourceGroup)
+		}
+		return fmt.Errorf("Error retrieving Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	if resp.ID == nil {
+		return fmt.Errorf("Error retrieving Virtual Machine Scale Set %q (Resource Group %q): `id` was nil", name, resourceGroup)
+	}
+
+	d.SetId(*resp.ID)
+
+	if location := resp.Location; location != nil {
+		d.Set("location", azure.NormalizeLocation(*location))
+	}
+	d.Set("zones", resp.Zones)
+
+	if err := d.Set("sku", flattenAzureRmVirtualMachineScaleSetSku(resp.Sku)); err != nil {
+		return fmt.Errorf("Error setting `sku`: %+v", err)
+	}
+
+	if props := resp.VirtualMachineScaleSetProperties; props != nil {
+		if policy := props.UpgradePolicy; policy != nil {
+			d.Set("upgrade_policy_mode", string(policy.Mode))
+		}
+	}
+
+	instances := make([]interface{}, 0)
+	iterator, err := vmsClient.ListComplete(ctx, resourceGroup, name, "", "", "instanceView")
+	if err != nil {
+		return fmt.Errorf("Error listing instances of Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	for iterator.NotDone() {
+		instance, err := flattenVirtualMachineScaleSetInstance(ctx, meta, resourceGroup, name, iterator.Value())
+		if err != nil {
+			return err
+		}
+		instances = append(instances, instance)
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("Error listing instances of Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
+	if err := d.Set("instances", instances); err != nil {
+		return fmt.Errorf("Error setting `instances`: %+v", err)
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func flattenVirtualMachineScaleSetInstance(ctx context.Context, meta interface{}, resourceGroup string, vmScaleSetName string, input compute.VirtualMachineScaleSetVM) (map[string]interface{}, error) {
+	nicClient := meta.(*ArmClient).ifaceClient
+
+	instanceId := ""
+	if input.InstanceID != nil {
+		instanceId = *input.InstanceID
+	}
+
+	name := ""
+	if input.Name != nil {
+		name = *input.Name
+	}
+
+	zone := ""
+	if input.Zones != nil && len(*input.Zones) > 0 {
+		zone = (*input.Zones)[0]
+	}
+
+	computerName := ""
+	latestModelApplied := false
+	powerState := ""
+	virtualMachineId := ""
+	if props := input.VirtualMachineScaleSetVMProperties; props != nil {
+		if props.OsProfile != nil && props.OsProfile.ComputerName != nil {
+			computerName = *props.OsProfile.ComputerName
+		}
+
+		if props.LatestModelApplied != nil {
+			latestModelApplied = *props.LatestModelApplied
+		}
+
+		if props.VMID != nil {
+			virtualMachineId = *props.VMID
+		}
+
+		if view := props.InstanceView; view != nil && view.Statuses != nil {
+			for _, status := range *view.Statuses {
+				if status.Code != nil && strings.HasPrefix(strings.ToLower(*status.Code), "powerstate/") {
+					powerState = strings.SplitN(*status.Code, "/", 2)[1]
+				}
+			}
+		}
+	}
+
+	// the Network Interfaces for a Scale Set instance live beneath the Scale Set, rather than as top-level resources
+	privateIPAddress := ""
+	privateIPAddresses := make([]interface{}, 0)
+	nics, err := nicClient.ListVirtualMachineScaleSetVMNetworkInterfacesComplete(ctx, resourceGroup, vmScaleSetName, instanceId)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing Network Interfaces for instance %q of Virtual Machine Scale Set %q (Resource Group %q): %+v", instanceId, vmScaleSetName, resourceGroup, err)
+	}
+	for nics.NotDone() {
+		nic := nics.Value()
+		if props := nic.InterfacePropertiesFormat; props != nil && props.IPConfigurations != nil {
+			isPrimaryNic := props.Primary != nil && *props.Primary
+			for _, config := range *props.IPConfigurations {
+				configProps := config.InterfaceIPConfigurationPropertiesFormat
+				if configProps == nil || configProps.PrivateIPAddress == nil {
+					continue
+				}
+
+				privateIPAddresses = append(privateIPAddresses, *configProps.PrivateIPAddress)
+
+				if isPrimaryNic && configProps.Primary != nil && *configProps.Primary {
+					privateIPAddress = *configProps.PrivateIPAddress
+				}
+			}
+		}
+
+		if err := nics.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("Error listing Network Interfaces for instance %q of Virtual Machine Scale Set %q (Resource Group %q): %+v", instanceId, vmScaleSetName, resourceGroup, err)
+		}
+	}
+
+	return map[string]interface{}{
+		"instance_id":          instanceId,
+		"name":                 name,
+		"computer_name":        computerName,
+		"latest_model_applied": latestModelApplied,
+		"power_state":          powerState,
+		"private_ip_address":   privateIPAddress,
+		"private_ip_addresses": privateIPAddresses,
+		"virtual_machine_id":   virtualMachineId,
+		"zone":                 zone,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+)
+
+func TestAccDataSourceAzureRMVirtualMachineScaleSet_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_virtual_machine_scale_set.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureRMVirtualMachineScaleSet_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "sku.0.capacity", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "upgrade_policy_mode", "Manual"),
+					resource.TestCheckResourceAttr(dataSourceName, "instances.#", "2"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "instances.0.private_ip_address"),
+					resource.TestCheckResourceAttr(dataSourceName, "instances.0.power_state", "running"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMVirtualMachineScaleSet_basic(rInt int, location string) string {
+	template := testAccAzureRMVirtualMachineScaleSet_basicLinux_managedDisk(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_virtual_machine_scale_set" "test" {
+  name                = "${azurerm_virtual_machine_scale_set.test.name}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+`, template)
+}
@@ -42,6 +42,38 @@ func ParseKeyVaultChildID(id string) (*KeyVaultChildID, error) {
 	return &childId, nil
 }
 
+// ParseKeyVaultChildIDVersionOptional parses a Key Vault Child ID which may or may not
+// include a Version - a versionless ID always refers to the latest version of the object
+func ParseKeyVaultChildIDVersionOptional(id string) (*KeyVaultChildID, error) {
+	// example: https://tharvey-keyvault.vault.azure.net/type/bird
+	idURL, err := url.ParseRequestURI(id)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse Azure KeyVault Child Id: %s", err)
+	}
+
+	path := idURL.Path
+
+	path = strings.TrimPrefix(path, "/")
+	path = strings.TrimSuffix(path, "/")
+
+	components := strings.Split(path, "/")
+
+	if len(components) != 2 && len(components) != 3 {
+		return nil, fmt.Errorf("Azure KeyVault Child Id should have 2 or 3 segments, got %d: '%s'", len(components), path)
+	}
+
+	childId := KeyVaultChildID{
+		KeyVaultBaseUrl: fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host),
+		Name:            components[1],
+	}
+
+	if len(components) == 3 {
+		childId.Version = components[2]
+	}
+
+	return &childId, nil
+}
+
 func ValidateKeyVaultChildName(v interface{}, k string) (warnings []string, errors []error) {
 	value := v.(string)
 
@@ -72,3 +104,22 @@ func ValidateKeyVaultChildId(i interface{}, k string) (warnings []string, errors
 
 	return warnings, errors
 }
+
+func ValidateKeyVaultChildIdVersionOptional(i interface{}, k string) (warnings []string, errors []error) {
+	if warnings, errors = validate.NoEmptyStrings(i, k); len(errors) > 0 {
+		return warnings, errors
+	}
+
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("Expected %s to be a string!", k))
+		return warnings, errors
+	}
+
+	if _, err := ParseKeyVaultChildIDVersionOptional(v); err != nil {
+		errors = append(errors, fmt.Errorf("Error parsing Key Vault Child ID: %s", err))
+		return warnings, errors
+	}
+
+	return warnings, errors
+}
@@ -213,3 +213,60 @@ func TestValidateMsSqlElasticPoolName(t *testing.T) {
 		}
 	}
 }
+
+//Your failover group name can contain only lowercase letters, numbers, and '-', but can't start or end with '-' or have more than 63 characters.
+func TestValidateMsSqlFailoverGroupName(t *testing.T) {
+	cases := []struct {
+		Value  string
+		Errors bool
+	}{
+		{
+			Value:  "",
+			Errors: true,
+		},
+		{
+			Value:  "f",
+			Errors: false,
+		},
+		{
+			Value:  "F",
+			Errors: true,
+		},
+		{
+			Value:  "-fog",
+			Errors: true,
+		},
+		{
+			Value:  "fog-",
+			Errors: true,
+		},
+		{
+			Value:  "my-failover-group",
+			Errors: false,
+		},
+		{
+			Value:  "my_failover_group",
+			Errors: true,
+		},
+		{
+			Value:  "123456789112345678921234567893123456789412345678951234567896123",
+			Errors: false,
+		},
+		{
+			Value:  "01234567891123456789212345678931234567894123456789512345678961234",
+			Errors: true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := ValidateMsSqlFailoverGroupName(tc.Value, "name")
+
+		if len(errors) > 0 != tc.Errors {
+			if tc.Errors {
+				t.Fatalf("Expected ValidateMsSqlFailoverGroupName to have errors for '%s', got %d ", tc.Value, len(errors))
+			} else {
+				t.Fatalf("Expected ValidateMsSqlFailoverGroupName to not have errors for '%s', got %d ", tc.Value, len(errors))
+			}
+		}
+	}
+}
@@ -122,3 +122,73 @@ func ParseKubeConfigAAD(config string) (*KubeConfigAAD, error) {
 
 	return &kubeConfig, nil
 }
+
+type userItemExec struct {
+	Name string   `yaml:"name"`
+	User userExec `yaml:"user"`
+}
+
+type userExec struct {
+	Exec execConfig `yaml:"exec"`
+}
+
+type execConfig struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Command    string   `yaml:"command"`
+	Args       []string `yaml:"args"`
+}
+
+type KubeConfigExec struct {
+	KubeConfigBase `yaml:",inline"`
+	Users          []userItemExec `yaml:"users"`
+}
+
+const (
+	ExecAPIVersion = "client.authentication.k8s.io/v1beta1"
+	ExecCommand    = "kubelogin"
+)
+
+// BuildKubeConfigExec converts an Azure Active Directory kubeconfig using the `azure` auth-provider into an
+// equivalent kubeconfig using the `kubelogin` exec credential plugin, returning both the kubeconfig and its YAML
+func BuildKubeConfigExec(config KubeConfigAAD, environment string) (*KubeConfigExec, string, error) {
+	if len(config.Clusters) <= 0 || len(config.Users) <= 0 {
+		return nil, "", fmt.Errorf("Config %+v contains no valid clusters or users", config)
+	}
+
+	users := make([]userItemExec, 0)
+	for _, item := range config.Users {
+		provider := item.User.AuthProvider.Config
+		if provider.APIServerID == "" || provider.ClientID == "" || provider.TenantID == "" {
+			return nil, "", fmt.Errorf("Config requires the `apiserver-id`, `client-id` and `tenant-id` for user %q", item.Name)
+		}
+
+		users = append(users, userItemExec{
+			Name: item.Name,
+			User: userExec{
+				Exec: execConfig{
+					APIVersion: ExecAPIVersion,
+					Command:    ExecCommand,
+					Args: []string{
+						"get-token",
+						"--environment", environment,
+						"--server-id", provider.APIServerID,
+						"--client-id", provider.ClientID,
+						"--tenant-id", provider.TenantID,
+					},
+				},
+			},
+		})
+	}
+
+	kubeConfig := KubeConfigExec{
+		KubeConfigBase: config.KubeConfigBase,
+		Users:          users,
+	}
+
+	raw, err := yaml.Marshal(kubeConfig)
+	if err != nil {
+		return nil, "", fmt.Errorf("Failed to marshal YAML config with error %+v", err)
+	}
+
+	return &kubeConfig, string(raw), nil
+}
@@ -192,3 +192,54 @@ func LoadConfig(fileName string) string {
 
 	return string(bytes)
 }
+
+func TestBuildKubeConfigExec(t *testing.T) {
+	aadConfig, err := ParseKubeConfigAAD(LoadConfig("user_with_aad.yml"))
+	if err != nil {
+		t.Fatalf("Failed to parse AAD config: %+v", err)
+	}
+
+	execConfig, raw, err := BuildKubeConfigExec(*aadConfig, "AzurePublicCloud")
+	if err != nil {
+		t.Fatalf("Failed to build exec config: %+v", err)
+	}
+
+	if !reflect.DeepEqual(aadConfig.KubeConfigBase, execConfig.KubeConfigBase) {
+		t.Fatalf("expected the clusters and contexts to be unchanged but got '%+v'", execConfig.KubeConfigBase)
+	}
+
+	if len(execConfig.Users) != 1 {
+		t.Fatalf("expected 1 user but got %d", len(execConfig.Users))
+	}
+
+	user := execConfig.Users[0]
+	if user.Name != "clusterUser_test-rg_test-cluster" {
+		t.Fatalf("expected the user name to be unchanged but got %q", user.Name)
+	}
+
+	expectedArgs := []string{
+		"get-token",
+		"--environment", "AzurePublicCloud",
+		"--server-id", "00000000-0000-0000-0000-000000000001",
+		"--client-id", "00000000-0000-0000-0000-000000000002",
+		"--tenant-id", "00000000-0000-0000-0000-000000000003",
+	}
+	if user.User.Exec.Command != ExecCommand || user.User.Exec.APIVersion != ExecAPIVersion || !reflect.DeepEqual(expectedArgs, user.User.Exec.Args) {
+		t.Fatalf("unexpected exec configuration '%+v'", user.User.Exec)
+	}
+
+	if _, err := ParseKubeConfigAAD(raw); err != nil {
+		t.Fatalf("expected the raw exec config to be valid but got: %+v", err)
+	}
+}
+
+func TestBuildKubeConfigExecMissingAuthProvider(t *testing.T) {
+	aadConfig, err := ParseKubeConfigAAD(LoadConfig("user_with_token.yml"))
+	if err != nil {
+		t.Fatalf("Failed to parse config: %+v", err)
+	}
+
+	if _, _, err := BuildKubeConfigExec(*aadConfig, "AzurePublicCloud"); err == nil {
+		t.Fatalf("expected an error when the auth-provider configuration is missing")
+	}
+}
@@ -8,11 +8,14 @@ import (
 )
 
 type Client struct {
+	AgentPoolsClient         containerservice.AgentPoolsClient
 	KubernetesClustersClient containerservice.ManagedClustersClient
 	GroupsClient             containerinstance.ContainerGroupsClient
 	RegistriesClient         containerregistry.RegistriesClient
 	ReplicationsClient       containerregistry.ReplicationsClient
 	ServicesClient           containerservice.ContainerServicesClient
+	TasksClient              containerregistry.TasksClient
+	WebhooksClient           containerregistry.WebhooksClient
 }
 
 func BuildClient(o *common.ClientOptions) *Client {
@@ -24,6 +27,12 @@ func BuildClient(o *common.ClientOptions) *Client {
 	c.ReplicationsClient = containerregistry.NewReplicationsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&c.ReplicationsClient.Client, o.ResourceManagerAuthorizer)
 
+	c.TasksClient = containerregistry.NewTasksClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&c.TasksClient.Client, o.ResourceManagerAuthorizer)
+
+	c.WebhooksClient = containerregistry.NewWebhooksClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&c.WebhooksClient.Client, o.ResourceManagerAuthorizer)
+
 	c.GroupsClient = containerinstance.NewContainerGroupsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&c.GroupsClient.Client, o.ResourceManagerAuthorizer)
 
@@ -32,6 +41,9 @@ func BuildClient(o *common.ClientOptions) *Client {
 	o.ConfigureClient(&c.ServicesClient.Client, o.ResourceManagerAuthorizer)
 
 	// AKS
+	c.AgentPoolsClient = containerservice.NewAgentPoolsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&c.AgentPoolsClient.Client, o.ResourceManagerAuthorizer)
+
 	c.KubernetesClustersClient = containerservice.NewManagedClustersClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&c.KubernetesClustersClient.Client, o.ResourceManagerAuthorizer)
 
@@ -0,0 +1,172 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2019-06-01/containerservice"
+	"github.com/hashicorp/go-version"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// listKubernetesServiceVersions returns the Kubernetes versions which are available for Managed Kubernetes Clusters
+// in the specified location, along with the ID of the Orchestrator Version Profile
+func listKubernetesServiceVersions(ctx context.Context, client containerservice.ContainerServicesClient, location string) (*string, []string, error) {
+	listResp, err := client.ListOrchestrators(ctx, location, "managedClusters")
+	if err != nil {
+		if utils.ResponseWasNotFound(listResp.Response) {
+			return nil, nil, fmt.Errorf("Error: No Kubernetes Service versions found for location %q", location)
+		}
+		return nil, nil, fmt.Errorf("Error retrieving Kubernetes Versions in %q: %+v", location, err)
+	}
+
+	versions := make([]string, 0)
+	if props := listResp.OrchestratorVersionProfileProperties; props != nil {
+		if orchestrators := props.Orchestrators; orchestrators != nil {
+			for _, rawV := range *orchestrators {
+				if rawV.OrchestratorType == nil || rawV.OrchestratorVersion == nil {
+					continue
+				}
+
+				orchestratorType := *rawV.OrchestratorType
+				if !strings.EqualFold(orchestratorType, "Kubernetes") {
+					log.Printf("[DEBUG] Orchestrator %q was not Kubernetes", orchestratorType)
+					continue
+				}
+
+				versions = append(versions, *rawV.OrchestratorVersion)
+			}
+		}
+	}
+
+	return listResp.ID, versions, nil
+}
+
+// validateKubernetesVersionIsAvailable ensures the Kubernetes version is offered by Azure in the specified location
+func validateKubernetesVersionIsAvailable(ctx context.Context, meta interface{}, location string, kubernetesVersion string) error {
+	client := meta.(*ArmClient).containers.ServicesClient
+
+	_, versions, err := listKubernetesServiceVersions(ctx, client, location)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range versions {
+		if v == kubernetesVersion {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Kubernetes version %q is not available in %q - available versions are: %s", kubernetesVersion, location, strings.Join(versions, ", "))
+}
+
+// validateKubernetesVersionUpgrade ensures that upgrading from the current to the target Kubernetes version
+// doesn't downgrade, change the major version or skip a minor version - which Azure doesn't support
+func validateKubernetesVersionUpgrade(currentVersion string, targetVersion string) error {
+	current, err := version.NewVersion(currentVersion)
+	if err != nil {
+		return fmt.Errorf("Error parsing the current Kubernetes version %q: %+v", currentVersion, err)
+	}
+
+	target, err := version.NewVersion(targetVersion)
+	if err != nil {
+		return fmt.Errorf("Error parsing the target Kubernetes version %q: %+v", targetVersion, err)
+	}
+
+	if target.LessThan(current) {
+		return fmt.Errorf("Kubernetes versions cannot be downgraded (from %q to %q)", currentVersion, targetVersion)
+	}
+
+	currentSegments := current.Segments()
+	targetSegments := target.Segments()
+	if currentSegments[0] != targetSegments[0] {
+		return fmt.Errorf("Kubernetes versions cannot be upgraded across major versions (from %q to %q)", currentVersion, targetVersion)
+	}
+
+	if targetSegments[1]-currentSegments[1] > 1 {
+		return fmt.Errorf("Kubernetes versions must be upgraded one minor version at a time (from %q to %q) - please upgrade to a %d.%d.x version first", currentVersion, targetVersion, currentSegments[0], currentSegments[1]+1)
+	}
+
+	return nil
+}
+
+// validateKubernetesNodePoolVersion ensures the version of a Node Pool doesn't exceed the version of the Control Plane
+func validateKubernetesNodePoolVersion(controlPlaneVersion string, nodePoolVersion string) error {
+	controlPlane, err := version.NewVersion(controlPlaneVersion)
+	if err != nil {
+		return fmt.Errorf("Error parsing the Control Plane Kubernetes version %q: %+v", controlPlaneVersion, err)
+	}
+
+	nodePool, err := version.NewVersion(nodePoolVersion)
+	if err != nil {
+		return fmt.Errorf("Error parsing the Node Pool Kubernetes version %q: %+v", nodePoolVersion, err)
+	}
+
+	if nodePool.GreaterThan(controlPlane) {
+		return fmt.Errorf("The Node Pool Kubernetes version %q cannot be newer than the Control Plane Kubernetes version %q", nodePoolVersion, controlPlaneVersion)
+	}
+
+	return nil
+}
+
+// listKubernetesClusterUpgradeVersions returns the versions which the Control Plane of the Managed Kubernetes Cluster
+// can be upgraded to, along with the versions each Node Pool can be upgraded to (keyed by the name of the Node Pool)
+func listKubernetesClusterUpgradeVersions(ctx context.Context, client containerservice.ManagedClustersClient, resourceGroup string, name string) ([]string, map[string][]string, error) {
+	resp, err := client.GetUpgradeProfile(ctx, resourceGroup, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error retrieving the Upgrade Profile for Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	controlPlaneVersions := make([]string, 0)
+	nodePoolVersions := make(map[string][]string)
+	if props := resp.ManagedClusterUpgradeProfileProperties; props != nil {
+		if props.ControlPlaneProfile != nil {
+			controlPlaneVersions = flattenKubernetesClusterUpgradeVersions(props.ControlPlaneProfile.Upgrades)
+		}
+
+		if props.AgentPoolProfiles != nil {
+			for _, profile := range *props.AgentPoolProfiles {
+				if profile.Name == nil {
+					continue
+				}
+
+				nodePoolVersions[*profile.Name] = flattenKubernetesClusterUpgradeVersions(profile.Upgrades)
+			}
+		}
+	}
+
+	return controlPlaneVersions, nodePoolVersions, nil
+}
+
+func flattenKubernetesClusterUpgradeVersions(input *[]containerservice.ManagedClusterPoolUpgradeProfileUpgradesItem) []string {
+	versions := make([]string, 0)
+	if input == nil {
+		return versions
+	}
+
+	for _, item := range *input {
+		if item.KubernetesVersion != nil {
+			versions = append(versions, *item.KubernetesVersion)
+		}
+	}
+
+	return versions
+}
+
+// validateKubernetesVersionIsUpgradable ensures the target Kubernetes version is one of the versions which Azure offers
+// as an upgrade from the current version
+func validateKubernetesVersionIsUpgradable(currentVersion string, targetVersion string, upgradeVersions []string) error {
+	for _, v := range upgradeVersions {
+		if v == targetVersion {
+			return nil
+		}
+	}
+
+	if len(upgradeVersions) == 0 {
+		return fmt.Errorf("Kubernetes version %q cannot be upgraded to %q - no upgrades are available", currentVersion, targetVersion)
+	}
+
+	return fmt.Errorf("Kubernetes version %q cannot be upgraded to %q - available upgrades are: %s", currentVersion, targetVersion, strings.Join(upgradeVersions, ", "))
+}
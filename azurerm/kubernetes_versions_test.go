@@ -0,0 +1,116 @@
+package azurerm
+
+import "testing"
+
+func TestAzureRMKubernetesVersionUpgrade(t *testing.T) {
+	cases := []struct {
+		Current string
+		Target  string
+		Valid   bool
+	}{
+		{
+			Current: "1.13.10",
+			Target:  "1.13.10",
+			Valid:   true,
+		},
+		{
+			Current: "1.13.10",
+			Target:  "1.13.11",
+			Valid:   true,
+		},
+		{
+			Current: "1.13.10",
+			Target:  "1.14.6",
+			Valid:   true,
+		},
+		{
+			Current: "1.13.10",
+			Target:  "1.15.3",
+			Valid:   false,
+		},
+		{
+			Current: "1.14.6",
+			Target:  "1.13.10",
+			Valid:   false,
+		},
+		{
+			Current: "1.14.6",
+			Target:  "2.0.0",
+			Valid:   false,
+		},
+		{
+			Current: "1.14.6",
+			Target:  "latest",
+			Valid:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateKubernetesVersionUpgrade(tc.Current, tc.Target)
+		if valid := err == nil; valid != tc.Valid {
+			t.Fatalf("Expected upgrading from %q to %q to be valid %t but got %t (%+v)", tc.Current, tc.Target, tc.Valid, valid, err)
+		}
+	}
+}
+
+func TestAzureRMKubernetesNodePoolVersion(t *testing.T) {
+	cases := []struct {
+		ControlPlane string
+		NodePool     string
+		Valid        bool
+	}{
+		{
+			ControlPlane: "1.14.6",
+			NodePool:     "1.14.6",
+			Valid:        true,
+		},
+		{
+			ControlPlane: "1.14.6",
+			NodePool:     "1.13.10",
+			Valid:        true,
+		},
+		{
+			ControlPlane: "1.13.10",
+			NodePool:     "1.14.6",
+			Valid:        false,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateKubernetesNodePoolVersion(tc.ControlPlane, tc.NodePool)
+		if valid := err == nil; valid != tc.Valid {
+			t.Fatalf("Expected Node Pool version %q with Control Plane version %q to be valid %t but got %t (%+v)", tc.NodePool, tc.ControlPlane, tc.Valid, valid, err)
+		}
+	}
+}
+
+func TestAzureRMKubernetesVersionIsUpgradable(t *testing.T) {
+	cases := []struct {
+		Target   string
+		Upgrades []string
+		Valid    bool
+	}{
+		{
+			Target:   "1.14.6",
+			Upgrades: []string{"1.13.11", "1.14.6"},
+			Valid:    true,
+		},
+		{
+			Target:   "1.14.7",
+			Upgrades: []string{"1.13.11", "1.14.6"},
+			Valid:    false,
+		},
+		{
+			Target:   "1.14.6",
+			Upgrades: []string{},
+			Valid:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateKubernetesVersionIsUpgradable("1.13.10", tc.Target, tc.Upgrades)
+		if valid := err == nil; valid != tc.Valid {
+			t.Fatalf("Expected upgrading to %q with the upgrades %+v to be valid %t but got %t (%+v)", tc.Target, tc.Upgrades, tc.Valid, valid, err)
+		}
+	}
+}
@@ -98,88 +98,91 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"azurerm_api_management":                         dataSourceApiManagementService(),
-			"azurerm_api_management_api":                     dataSourceApiManagementApi(),
-			"azurerm_api_management_group":                   dataSourceApiManagementGroup(),
-			"azurerm_api_management_product":                 dataSourceApiManagementProduct(),
-			"azurerm_api_management_user":                    dataSourceArmApiManagementUser(),
-			"azurerm_app_service_plan":                       dataSourceAppServicePlan(),
-			"azurerm_app_service":                            dataSourceArmAppService(),
-			"azurerm_application_insights":                   dataSourceArmApplicationInsights(),
-			"azurerm_application_security_group":             dataSourceArmApplicationSecurityGroup(),
-			"azurerm_automation_variable_bool":               dataSourceArmAutomationVariableBool(),
-			"azurerm_automation_variable_datetime":           dataSourceArmAutomationVariableDateTime(),
-			"azurerm_automation_variable_int":                dataSourceArmAutomationVariableInt(),
-			"azurerm_automation_variable_string":             dataSourceArmAutomationVariableString(),
-			"azurerm_availability_set":                       dataSourceArmAvailabilitySet(),
-			"azurerm_azuread_application":                    dataSourceArmAzureADApplication(),
-			"azurerm_azuread_service_principal":              dataSourceArmActiveDirectoryServicePrincipal(),
-			"azurerm_batch_account":                          dataSourceArmBatchAccount(),
-			"azurerm_batch_certificate":                      dataSourceArmBatchCertificate(),
-			"azurerm_batch_pool":                             dataSourceArmBatchPool(),
-			"azurerm_builtin_role_definition":                dataSourceArmBuiltInRoleDefinition(),
-			"azurerm_cdn_profile":                            dataSourceArmCdnProfile(),
-			"azurerm_client_config":                          dataSourceArmClientConfig(),
-			"azurerm_kubernetes_service_versions":            dataSourceArmKubernetesServiceVersions(),
-			"azurerm_container_registry":                     dataSourceArmContainerRegistry(),
-			"azurerm_cosmosdb_account":                       dataSourceArmCosmosDbAccount(),
-			"azurerm_data_lake_store":                        dataSourceArmDataLakeStoreAccount(),
-			"azurerm_dev_test_lab":                           dataSourceArmDevTestLab(),
-			"azurerm_dns_zone":                               dataSourceArmDnsZone(),
-			"azurerm_eventhub_namespace":                     dataSourceEventHubNamespace(),
-			"azurerm_express_route_circuit":                  dataSourceArmExpressRouteCircuit(),
-			"azurerm_firewall":                               dataSourceArmFirewall(),
-			"azurerm_image":                                  dataSourceArmImage(),
-			"azurerm_hdinsight_cluster":                      dataSourceArmHDInsightSparkCluster(),
-			"azurerm_key_vault_access_policy":                dataSourceArmKeyVaultAccessPolicy(),
-			"azurerm_key_vault_key":                          dataSourceArmKeyVaultKey(),
-			"azurerm_key_vault_secret":                       dataSourceArmKeyVaultSecret(),
-			"azurerm_key_vault":                              dataSourceArmKeyVault(),
-			"azurerm_kubernetes_cluster":                     dataSourceArmKubernetesCluster(),
-			"azurerm_lb":                                     dataSourceArmLoadBalancer(),
-			"azurerm_lb_backend_address_pool":                dataSourceArmLoadBalancerBackendAddressPool(),
-			"azurerm_log_analytics_workspace":                dataSourceLogAnalyticsWorkspace(),
-			"azurerm_logic_app_workflow":                     dataSourceArmLogicAppWorkflow(),
-			"azurerm_managed_disk":                           dataSourceArmManagedDisk(),
-			"azurerm_management_group":                       dataSourceArmManagementGroup(),
-			"azurerm_monitor_action_group":                   dataSourceArmMonitorActionGroup(),
-			"azurerm_monitor_diagnostic_categories":          dataSourceArmMonitorDiagnosticCategories(),
-			"azurerm_monitor_log_profile":                    dataSourceArmMonitorLogProfile(),
-			"azurerm_mssql_elasticpool":                      dataSourceArmMsSqlElasticpool(),
-			"azurerm_network_interface":                      dataSourceArmNetworkInterface(),
-			"azurerm_network_security_group":                 dataSourceArmNetworkSecurityGroup(),
-			"azurerm_network_watcher":                        dataSourceArmNetworkWatcher(),
-			"azurerm_notification_hub_namespace":             dataSourceNotificationHubNamespace(),
-			"azurerm_notification_hub":                       dataSourceNotificationHub(),
-			"azurerm_platform_image":                         dataSourceArmPlatformImage(),
-			"azurerm_policy_definition":                      dataSourceArmPolicyDefinition(),
-			"azurerm_public_ip":                              dataSourceArmPublicIP(),
-			"azurerm_public_ips":                             dataSourceArmPublicIPs(),
-			"azurerm_recovery_services_vault":                dataSourceArmRecoveryServicesVault(),
-			"azurerm_recovery_services_protection_policy_vm": dataSourceArmRecoveryServicesProtectionPolicyVm(),
-			"azurerm_redis_cache":                            dataSourceArmRedisCache(),
-			"azurerm_resource_group":                         dataSourceArmResourceGroup(),
-			"azurerm_role_definition":                        dataSourceArmRoleDefinition(),
-			"azurerm_route_table":                            dataSourceArmRouteTable(),
-			"azurerm_scheduler_job_collection":               dataSourceArmSchedulerJobCollection(),
-			"azurerm_servicebus_namespace":                   dataSourceArmServiceBusNamespace(),
-			"azurerm_shared_image_gallery":                   dataSourceArmSharedImageGallery(),
-			"azurerm_shared_image_version":                   dataSourceArmSharedImageVersion(),
-			"azurerm_shared_image":                           dataSourceArmSharedImage(),
-			"azurerm_snapshot":                               dataSourceArmSnapshot(),
-			"azurerm_sql_server":                             dataSourceSqlServer(),
-			"azurerm_stream_analytics_job":                   dataSourceArmStreamAnalyticsJob(),
-			"azurerm_storage_account_sas":                    dataSourceArmStorageAccountSharedAccessSignature(),
-			"azurerm_storage_account":                        dataSourceArmStorageAccount(),
-			"azurerm_subnet":                                 dataSourceArmSubnet(),
-			"azurerm_subscription":                           dataSourceArmSubscription(),
-			"azurerm_subscriptions":                          dataSourceArmSubscriptions(),
-			"azurerm_traffic_manager_geographical_location":  dataSourceArmTrafficManagerGeographicalLocation(),
-			"azurerm_user_assigned_identity":                 dataSourceArmUserAssignedIdentity(),
-			"azurerm_virtual_machine":                        dataSourceArmVirtualMachine(),
-			"azurerm_virtual_network_gateway":                dataSourceArmVirtualNetworkGateway(),
-			"azurerm_virtual_network_gateway_connection":     dataSourceArmVirtualNetworkGatewayConnection(),
-			"azurerm_virtual_network":                        dataSourceArmVirtualNetwork(),
+			"azurerm_api_management":                             dataSourceApiManagementService(),
+			"azurerm_api_management_api":                         dataSourceApiManagementApi(),
+			"azurerm_api_management_group":                       dataSourceApiManagementGroup(),
+			"azurerm_api_management_product":                     dataSourceApiManagementProduct(),
+			"azurerm_api_management_user":                        dataSourceArmApiManagementUser(),
+			"azurerm_app_service_plan":                           dataSourceAppServicePlan(),
+			"azurerm_app_service":                                dataSourceArmAppService(),
+			"azurerm_application_insights":                       dataSourceArmApplicationInsights(),
+			"azurerm_application_security_group":                 dataSourceArmApplicationSecurityGroup(),
+			"azurerm_automation_variable_bool":                   dataSourceArmAutomationVariableBool(),
+			"azurerm_automation_variable_datetime":               dataSourceArmAutomationVariableDateTime(),
+			"azurerm_automation_variable_int":                    dataSourceArmAutomationVariableInt(),
+			"azurerm_automation_variable_string":                 dataSourceArmAutomationVariableString(),
+			"azurerm_availability_set":                           dataSourceArmAvailabilitySet(),
+			"azurerm_azuread_application":                        dataSourceArmAzureADApplication(),
+			"azurerm_azuread_service_principal":                  dataSourceArmActiveDirectoryServicePrincipal(),
+			"azurerm_batch_account":                              dataSourceArmBatchAccount(),
+			"azurerm_batch_certificate":                          dataSourceArmBatchCertificate(),
+			"azurerm_batch_pool":                                 dataSourceArmBatchPool(),
+			"azurerm_builtin_role_definition":                    dataSourceArmBuiltInRoleDefinition(),
+			"azurerm_cdn_profile":                                dataSourceArmCdnProfile(),
+			"azurerm_client_config":                              dataSourceArmClientConfig(),
+			"azurerm_kubernetes_service_versions":                dataSourceArmKubernetesServiceVersions(),
+			"azurerm_container_registry":                         dataSourceArmContainerRegistry(),
+			"azurerm_cosmosdb_account":                           dataSourceArmCosmosDbAccount(),
+			"azurerm_data_lake_store":                            dataSourceArmDataLakeStoreAccount(),
+			"azurerm_dev_test_lab":                               dataSourceArmDevTestLab(),
+			"azurerm_dns_zone":                                   dataSourceArmDnsZone(),
+			"azurerm_eventhub_namespace":                         dataSourceEventHubNamespace(),
+			"azurerm_express_route_circuit":                      dataSourceArmExpressRouteCircuit(),
+			"azurerm_firewall":                                   dataSourceArmFirewall(),
+			"azurerm_image":                                      dataSourceArmImage(),
+			"azurerm_hdinsight_cluster":                          dataSourceArmHDInsightSparkCluster(),
+			"azurerm_key_vault_access_policy":                    dataSourceArmKeyVaultAccessPolicy(),
+			"azurerm_key_vault_key":                              dataSourceArmKeyVaultKey(),
+			"azurerm_key_vault_secret":                           dataSourceArmKeyVaultSecret(),
+			"azurerm_key_vault":                                  dataSourceArmKeyVault(),
+			"azurerm_kubernetes_cluster":                         dataSourceArmKubernetesCluster(),
+			"azurerm_lb":                                         dataSourceArmLoadBalancer(),
+			"azurerm_lb_backend_address_pool":                    dataSourceArmLoadBalancerBackendAddressPool(),
+			"azurerm_log_analytics_workspace":                    dataSourceLogAnalyticsWorkspace(),
+			"azurerm_logic_app_workflow":                         dataSourceArmLogicAppWorkflow(),
+			"azurerm_managed_disk":                               dataSourceArmManagedDisk(),
+			"azurerm_management_group":                           dataSourceArmManagementGroup(),
+			"azurerm_monitor_action_group":                       dataSourceArmMonitorActionGroup(),
+			"azurerm_monitor_diagnostic_categories":              dataSourceArmMonitorDiagnosticCategories(),
+			"azurerm_monitor_log_profile":                        dataSourceArmMonitorLogProfile(),
+			"azurerm_mssql_elasticpool":                          dataSourceArmMsSqlElasticpool(),
+			"azurerm_network_interface":                          dataSourceArmNetworkInterface(),
+			"azurerm_network_interface_effective_routes":         dataSourceArmNetworkInterfaceEffectiveRoutes(),
+			"azurerm_network_interface_effective_security_rules": dataSourceArmNetworkInterfaceEffectiveSecurityRules(),
+			"azurerm_network_security_group":                     dataSourceArmNetworkSecurityGroup(),
+			"azurerm_network_watcher":                            dataSourceArmNetworkWatcher(),
+			"azurerm_notification_hub_namespace":                 dataSourceNotificationHubNamespace(),
+			"azurerm_notification_hub":                           dataSourceNotificationHub(),
+			"azurerm_platform_image":                             dataSourceArmPlatformImage(),
+			"azurerm_policy_definition":                          dataSourceArmPolicyDefinition(),
+			"azurerm_public_ip":                                  dataSourceArmPublicIP(),
+			"azurerm_public_ips":                                 dataSourceArmPublicIPs(),
+			"azurerm_recovery_services_vault":                    dataSourceArmRecoveryServicesVault(),
+			"azurerm_recovery_services_protection_policy_vm":     dataSourceArmRecoveryServicesProtectionPolicyVm(),
+			"azurerm_redis_cache":                                dataSourceArmRedisCache(),
+			"azurerm_resource_group":                             dataSourceArmResourceGroup(),
+			"azurerm_role_definition":                            dataSourceArmRoleDefinition(),
+			"azurerm_route_table":                                dataSourceArmRouteTable(),
+			"azurerm_scheduler_job_collection":                   dataSourceArmSchedulerJobCollection(),
+			"azurerm_servicebus_namespace":                       dataSourceArmServiceBusNamespace(),
+			"azurerm_shared_image_gallery":                       dataSourceArmSharedImageGallery(),
+			"azurerm_shared_image_version":                       dataSourceArmSharedImageVersion(),
+			"azurerm_shared_image":                               dataSourceArmSharedImage(),
+			"azurerm_snapshot":                                   dataSourceArmSnapshot(),
+			"azurerm_sql_server":                                 dataSourceSqlServer(),
+			"azurerm_stream_analytics_job":                       dataSourceArmStreamAnalyticsJob(),
+			"azurerm_storage_account_sas":                        dataSourceArmStorageAccountSharedAccessSignature(),
+			"azurerm_storage_account":                            dataSourceArmStorageAccount(),
+			"azurerm_subnet":                                     dataSourceArmSubnet(),
+			"azurerm_subscription":                               dataSourceArmSubscription(),
+			"azurerm_subscriptions":                              dataSourceArmSubscriptions(),
+			"azurerm_traffic_manager_geographical_location":      dataSourceArmTrafficManagerGeographicalLocation(),
+			"azurerm_user_assigned_identity":                     dataSourceArmUserAssignedIdentity(),
+			"azurerm_virtual_machine":                            dataSourceArmVirtualMachine(),
+			"azurerm_virtual_machine_scale_set":                  dataSourceArmVirtualMachineScaleSet(),
+			"azurerm_virtual_network_gateway":                    dataSourceArmVirtualNetworkGateway(),
+			"azurerm_virtual_network_gateway_connection":         dataSourceArmVirtualNetworkGatewayConnection(),
+			"azurerm_virtual_network":                            dataSourceArmVirtualNetwork(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -240,6 +243,9 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_connection_monitor":                                 resourceArmConnectionMonitor(),
 			"azurerm_container_group":                                    resourceArmContainerGroup(),
 			"azurerm_container_registry":                                 resourceArmContainerRegistry(),
+			"azurerm_container_registry_image_import":                    resourceArmContainerRegistryImageImport(),
+			"azurerm_container_registry_task":                            resourceArmContainerRegistryTask(),
+			"azurerm_container_registry_webhook":                         resourceArmContainerRegistryWebhook(),
 			"azurerm_container_service":                                  resourceArmContainerService(),
 			"azurerm_cosmosdb_account":                                   resourceArmCosmosDbAccount(),
 			"azurerm_cosmosdb_cassandra_keyspace":                        resourceArmCosmosDbCassandraKeyspace(),
@@ -315,6 +321,7 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_key_vault_secret":                                   resourceArmKeyVaultSecret(),
 			"azurerm_key_vault":                                          resourceArmKeyVault(),
 			"azurerm_kubernetes_cluster":                                 resourceArmKubernetesCluster(),
+			"azurerm_kubernetes_cluster_node_pool":                       resourceArmKubernetesClusterNodePool(),
 			"azurerm_lb_backend_address_pool":                            resourceArmLoadBalancerBackendAddressPool(),
 			"azurerm_lb_nat_pool":                                        resourceArmLoadBalancerNatPool(),
 			"azurerm_lb_nat_rule":                                        resourceArmLoadBalancerNatRule(),
@@ -322,6 +329,7 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_lb_outbound_rule":                                   resourceArmLoadBalancerOutboundRule(),
 			"azurerm_lb_rule":                                            resourceArmLoadBalancerRule(),
 			"azurerm_lb":                                                 resourceArmLoadBalancer(),
+			"azurerm_linux_virtual_machine":                              resourceArmLinuxVirtualMachine(),
 			"azurerm_local_network_gateway":                              resourceArmLocalNetworkGateway(),
 			"azurerm_log_analytics_solution":                             resourceArmLogAnalyticsSolution(),
 			"azurerm_log_analytics_linked_service":                       resourceArmLogAnalyticsLinkedService(),
@@ -379,6 +387,7 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_postgresql_server":                                                      resourceArmPostgreSQLServer(),
 			"azurerm_postgresql_virtual_network_rule":                                        resourceArmPostgreSQLVirtualNetworkRule(),
 			"azurerm_private_dns_zone":                                                       resourceArmPrivateDnsZone(),
+			"azurerm_proximity_placement_group":                                              resourceArmProximityPlacementGroup(),
 			"azurerm_public_ip":                                                              resourceArmPublicIp(),
 			"azurerm_public_ip_prefix":                                                       resourceArmPublicIpPrefix(),
 			"azurerm_recovery_services_protected_vm":                                         resourceArmRecoveryServicesProtectedVm(),
@@ -415,6 +424,7 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_sql_active_directory_administrator":                                     resourceArmSqlAdministrator(),
 			"azurerm_sql_database":                                                           resourceArmSqlDatabase(),
 			"azurerm_sql_elasticpool":                                                        resourceArmSqlElasticPool(),
+			"azurerm_sql_failover_group":                                                     resourceArmSqlFailoverGroup(),
 			"azurerm_sql_firewall_rule":                                                      resourceArmSqlFirewallRule(),
 			"azurerm_sql_server":                                                             resourceArmSqlServer(),
 			"azurerm_sql_virtual_network_rule":                                               resourceArmSqlVirtualNetworkRule(),
@@ -442,14 +452,21 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_traffic_manager_endpoint":                                               resourceArmTrafficManagerEndpoint(),
 			"azurerm_traffic_manager_profile":                                                resourceArmTrafficManagerProfile(),
 			"azurerm_user_assigned_identity":                                                 resourceArmUserAssignedIdentity(),
+			"azurerm_virtual_hub":                                                            resourceArmVirtualHub(),
 			"azurerm_virtual_machine_data_disk_attachment":                                   resourceArmVirtualMachineDataDiskAttachment(),
 			"azurerm_virtual_machine_extension":                                              resourceArmVirtualMachineExtensions(),
 			"azurerm_virtual_machine_scale_set":                                              resourceArmVirtualMachineScaleSet(),
+			"azurerm_virtual_machine_scale_set_extension":                                    resourceArmVirtualMachineScaleSetExtension(),
 			"azurerm_virtual_machine":                                                        resourceArmVirtualMachine(),
 			"azurerm_virtual_network_gateway_connection":                                     resourceArmVirtualNetworkGatewayConnection(),
 			"azurerm_virtual_network_gateway":                                                resourceArmVirtualNetworkGateway(),
 			"azurerm_virtual_network_peering":                                                resourceArmVirtualNetworkPeering(),
 			"azurerm_virtual_network":                                                        resourceArmVirtualNetwork(),
+			"azurerm_virtual_wan":                                                            resourceArmVirtualWan(),
+			"azurerm_vpn_gateway":                                                            resourceArmVpnGateway(),
+			"azurerm_vpn_site":                                                               resourceArmVpnSite(),
+			"azurerm_web_application_firewall_policy":                                        resourceArmWebApplicationFirewallPolicy(),
+			"azurerm_windows_virtual_machine":                                                resourceArmWindowsVirtualMachine(),
 		},
 	}
 
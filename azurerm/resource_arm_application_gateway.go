@@ -3,6 +3,7 @@ package azurerm
 import (
 	"fmt"
 	"log"
+	"sort"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -50,6 +51,33 @@ func resourceArmApplicationGateway() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"identity": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  string(network.ResourceIdentityTypeUserAssigned),
+							ValidateFunc: validation.StringInSlice([]string{
+								string(network.ResourceIdentityTypeUserAssigned),
+							}, false),
+						},
+						"identity_ids": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: azure.ValidateResourceID,
+							},
+						},
+					},
+				},
+			},
+
 			// Required
 			"backend_address_pool": {
 				Type:     schema.TypeList,
@@ -945,17 +973,23 @@ func resourceArmApplicationGateway() *schema.Resource {
 
 						"data": {
 							Type:      schema.TypeString,
-							Required:  true,
+							Optional:  true,
 							Sensitive: true,
 							StateFunc: base64EncodedStateFunc,
 						},
 
 						"password": {
 							Type:      schema.TypeString,
-							Required:  true,
+							Optional:  true,
 							Sensitive: true,
 						},
 
+						"key_vault_secret_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: azure.ValidateKeyVaultChildIdVersionOptional,
+						},
+
 						"id": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -1097,6 +1131,12 @@ func resourceArmApplicationGateway() *schema.Resource {
 				},
 			},
 
+			"firewall_policy_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
 			"waf_configuration": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -1304,7 +1344,10 @@ func resourceArmApplicationGatewayCreateUpdate(d *schema.ResourceData, meta inte
 	probes := expandApplicationGatewayProbes(d)
 	sku := expandApplicationGatewaySku(d)
 	autoscaleConfiguration := expandApplicationGatewayAutoscaleConfiguration(d)
-	sslCertificates := expandApplicationGatewaySslCertificates(d)
+	sslCertificates, err := expandApplicationGatewaySslCertificates(d)
+	if err != nil {
+		return fmt.Errorf("Error expanding `ssl_certificate`: %+v", err)
+	}
 	sslPolicy := expandApplicationGatewaySslPolicy(d)
 	customErrorConfigurations := expandApplicationGatewayCustomErrorConfigurations(d.Get("custom_error_configuration").([]interface{}))
 	rewriteRuleSets := expandApplicationGatewayRewriteRuleSets(d)
@@ -1380,6 +1423,16 @@ func resourceArmApplicationGatewayCreateUpdate(d *schema.ResourceData, meta inte
 		}
 	}
 
+	if _, ok := d.GetOk("identity"); ok || d.HasChange("identity") {
+		gateway.Identity = expandApplicationGatewayIdentity(d)
+	}
+
+	if v, ok := d.GetOk("firewall_policy_id"); ok {
+		gateway.ApplicationGatewayPropertiesFormat.FirewallPolicy = &network.SubResource{
+			ID: utils.String(v.(string)),
+		}
+	}
+
 	if _, ok := d.GetOk("waf_configuration"); ok {
 		gateway.ApplicationGatewayPropertiesFormat.WebApplicationFirewallConfiguration = expandApplicationGatewayWafConfig(d)
 	}
@@ -1457,6 +1510,10 @@ func resourceArmApplicationGatewayRead(d *schema.ResourceData, meta interface{})
 	}
 	d.Set("zones", applicationGateway.Zones)
 
+	if err := d.Set("identity", flattenApplicationGatewayIdentity(applicationGateway.Identity)); err != nil {
+		return fmt.Errorf("Error setting `identity`: %+v", err)
+	}
+
 	if props := applicationGateway.ApplicationGatewayPropertiesFormat; props != nil {
 		flattenedCerts := flattenApplicationGatewayAuthenticationCertificates(props.AuthenticationCertificates, d)
 		if setErr := d.Set("authentication_certificate", flattenedCerts); setErr != nil {
@@ -1557,6 +1614,12 @@ func resourceArmApplicationGatewayRead(d *schema.ResourceData, meta interface{})
 		if setErr := d.Set("waf_configuration", flattenApplicationGatewayWafConfig(props.WebApplicationFirewallConfiguration)); setErr != nil {
 			return fmt.Errorf("Error setting `waf_configuration`: %+v", setErr)
 		}
+
+		firewallPolicyId := ""
+		if props.FirewallPolicy != nil && props.FirewallPolicy.ID != nil {
+			firewallPolicyId = *props.FirewallPolicy.ID
+		}
+		d.Set("firewall_policy_id", firewallPolicyId)
 	}
 
 	flattenAndSetTags(d, applicationGateway.Tags)
@@ -3025,7 +3088,7 @@ func flattenApplicationGatewaySku(input *network.ApplicationGatewaySku) []interf
 	return []interface{}{result}
 }
 
-func expandApplicationGatewaySslCertificates(d *schema.ResourceData) *[]network.ApplicationGatewaySslCertificate {
+func expandApplicationGatewaySslCertificates(d *schema.ResourceData) (*[]network.ApplicationGatewaySslCertificate, error) {
 	vs := d.Get("ssl_certificate").([]interface{})
 	results := make([]network.ApplicationGatewaySslCertificate, 0)
 
@@ -3035,22 +3098,39 @@ func expandApplicationGatewaySslCertificates(d *schema.ResourceData) *[]network.
 		name := v["name"].(string)
 		data := v["data"].(string)
 		password := v["password"].(string)
-
-		// data must be base64 encoded
-		data = base64Encode(data)
+		keyVaultSecretId := v["key_vault_secret_id"].(string)
 
 		output := network.ApplicationGatewaySslCertificate{
 			Name: utils.String(name),
-			ApplicationGatewaySslCertificatePropertiesFormat: &network.ApplicationGatewaySslCertificatePropertiesFormat{
-				Data:     utils.String(data),
-				Password: utils.String(password),
-			},
+			ApplicationGatewaySslCertificatePropertiesFormat: &network.ApplicationGatewaySslCertificatePropertiesFormat{},
+		}
+
+		if data != "" && keyVaultSecretId != "" {
+			return nil, fmt.Errorf("only one of `data` or `key_vault_secret_id` can be set for SSL Certificate %q", name)
+		}
+
+		if data != "" {
+			if password == "" {
+				return nil, fmt.Errorf("`password` is required when `data` is set for SSL Certificate %q", name)
+			}
+
+			// data must be base64 encoded
+			output.ApplicationGatewaySslCertificatePropertiesFormat.Data = utils.String(base64Encode(data))
+			output.ApplicationGatewaySslCertificatePropertiesFormat.Password = utils.String(password)
+		} else if keyVaultSecretId != "" {
+			if _, ok := d.GetOk("identity"); !ok {
+				return nil, fmt.Errorf("an `identity` block is required to use `key_vault_secret_id` for SSL Certificate %q", name)
+			}
+
+			output.ApplicationGatewaySslCertificatePropertiesFormat.KeyVaultSecretID = utils.String(keyVaultSecretId)
+		} else {
+			return nil, fmt.Errorf("either `data` or `key_vault_secret_id` must be set for SSL Certificate %q", name)
 		}
 
 		results = append(results, output)
 	}
 
-	return &results
+	return &results, nil
 }
 
 func flattenApplicationGatewaySslCertificates(input *[]network.ApplicationGatewaySslCertificate, d *schema.ResourceData) []interface{} {
@@ -3077,6 +3157,12 @@ func flattenApplicationGatewaySslCertificates(input *[]network.ApplicationGatewa
 			if data := props.PublicCertData; data != nil {
 				output["public_cert_data"] = *data
 			}
+
+			keyVaultSecretId := ""
+			if props.KeyVaultSecretID != nil {
+				keyVaultSecretId = *props.KeyVaultSecretID
+			}
+			output["key_vault_secret_id"] = keyVaultSecretId
 		}
 
 		// since the certificate data isn't returned we have to load it from the same index
@@ -3105,6 +3191,48 @@ func flattenApplicationGatewaySslCertificates(input *[]network.ApplicationGatewa
 	return results
 }
 
+func expandApplicationGatewayIdentity(d *schema.ResourceData) *network.ManagedServiceIdentity {
+	v := d.Get("identity").([]interface{})
+	if len(v) == 0 {
+		// explicitly send `None` so that removing the block clears an existing identity
+		return &network.ManagedServiceIdentity{
+			Type: network.ResourceIdentityTypeNone,
+		}
+	}
+	identity := v[0].(map[string]interface{})
+
+	identityIds := make(map[string]*network.ManagedServiceIdentityUserAssignedIdentitiesValue)
+	for _, id := range identity["identity_ids"].([]interface{}) {
+		identityIds[id.(string)] = &network.ManagedServiceIdentityUserAssignedIdentitiesValue{}
+	}
+
+	return &network.ManagedServiceIdentity{
+		Type:                   network.ResourceIdentityType(identity["type"].(string)),
+		UserAssignedIdentities: identityIds,
+	}
+}
+
+func flattenApplicationGatewayIdentity(identity *network.ManagedServiceIdentity) []interface{} {
+	if identity == nil || identity.Type == network.ResourceIdentityTypeNone {
+		return make([]interface{}, 0)
+	}
+
+	result := make(map[string]interface{})
+	result["type"] = string(identity.Type)
+
+	identityIds := make([]string, 0)
+	if identity.UserAssignedIdentities != nil {
+		for key := range identity.UserAssignedIdentities {
+			identityIds = append(identityIds, key)
+		}
+	}
+	// the API returns the identities as a map, so sort them to keep the list stable
+	sort.Strings(identityIds)
+	result["identity_ids"] = identityIds
+
+	return []interface{}{result}
+}
+
 func expandApplicationGatewayURLPathMaps(d *schema.ResourceData, gatewayID string) (*[]network.ApplicationGatewayURLPathMap, error) {
 	vs := d.Get("url_path_map").([]interface{})
 	results := make([]network.ApplicationGatewayURLPathMap, 0)
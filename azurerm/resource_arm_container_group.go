@@ -47,9 +47,17 @@ func resourceArmContainerGroup() *schema.Resource {
 				DiffSuppressFunc: suppress.CaseDifference,
 				ValidateFunc: validation.StringInSlice([]string{
 					string(containerinstance.Public),
+					string(containerinstance.Private),
 				}, true),
 			},
 
+			"network_profile_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
 			"os_type": {
 				Type:             schema.TypeString,
 				Required:         true,
@@ -322,24 +330,65 @@ func resourceArmContainerGroup() *schema.Resource {
 
 									"share_name": {
 										Type:         schema.TypeString,
-										Required:     true,
+										Optional:     true,
 										ForceNew:     true,
 										ValidateFunc: validate.NoEmptyStrings,
 									},
 
 									"storage_account_name": {
 										Type:         schema.TypeString,
-										Required:     true,
+										Optional:     true,
 										ForceNew:     true,
 										ValidateFunc: validate.NoEmptyStrings,
 									},
 
 									"storage_account_key": {
 										Type:         schema.TypeString,
-										Required:     true,
+										Optional:     true,
 										ForceNew:     true,
 										ValidateFunc: validate.NoEmptyStrings,
 									},
+
+									"secret": {
+										Type:      schema.TypeMap,
+										Optional:  true,
+										ForceNew:  true,
+										Sensitive: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+
+									"git_repo": {
+										Type:     schema.TypeList,
+										Optional: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"url": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ForceNew:     true,
+													ValidateFunc: validate.NoEmptyStrings,
+												},
+
+												"directory": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ForceNew:     true,
+													ValidateFunc: validate.NoEmptyStrings,
+												},
+
+												"revision": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ForceNew:     true,
+													ValidateFunc: validate.NoEmptyStrings,
+												},
+											},
+										},
+									},
 								},
 							},
 						},
@@ -447,7 +496,10 @@ func resourceArmContainerGroupCreate(d *schema.ResourceData, meta interface{}) e
 	diagnosticsRaw := d.Get("diagnostics").([]interface{})
 	diagnostics := expandContainerGroupDiagnostics(diagnosticsRaw)
 
-	containers, containerGroupPorts, containerGroupVolumes := expandContainerGroupContainers(d)
+	containers, containerGroupPorts, containerGroupVolumes, err := expandContainerGroupContainers(d)
+	if err != nil {
+		return err
+	}
 	containerGroup := containerinstance.ContainerGroup{
 		Name:     &name,
 		Location: &location,
@@ -467,10 +519,30 @@ func resourceArmContainerGroupCreate(d *schema.ResourceData, meta interface{}) e
 		},
 	}
 
-	if dnsNameLabel := d.Get("dns_name_label").(string); dnsNameLabel != "" {
+	dnsNameLabel := d.Get("dns_name_label").(string)
+	networkProfileId := d.Get("network_profile_id").(string)
+	if strings.EqualFold(IPAddressType, string(containerinstance.Private)) {
+		if networkProfileId == "" {
+			return fmt.Errorf("Error: `network_profile_id` must be specified when `ip_address_type` is `Private`")
+		}
+
+		if dnsNameLabel != "" {
+			return fmt.Errorf("Error: `dns_name_label` cannot be specified when `ip_address_type` is `Private`")
+		}
+	} else if networkProfileId != "" {
+		return fmt.Errorf("Error: `network_profile_id` can only be specified when `ip_address_type` is `Private`")
+	}
+
+	if dnsNameLabel != "" {
 		containerGroup.ContainerGroupProperties.IPAddress.DNSNameLabel = &dnsNameLabel
 	}
 
+	if networkProfileId != "" {
+		containerGroup.ContainerGroupProperties.NetworkProfile = &containerinstance.ContainerGroupNetworkProfile{
+			ID: utils.String(networkProfileId),
+		}
+	}
+
 	future, err := client.CreateOrUpdate(ctx, resGroup, name, containerGroup)
 	if err != nil {
 		return fmt.Errorf("Error creating/updating container group %q (Resource Group %q): %+v", name, resGroup, err)
@@ -544,6 +616,12 @@ func resourceArmContainerGroupRead(d *schema.ResourceData, meta interface{}) err
 			d.Set("fqdn", address.Fqdn)
 		}
 
+		networkProfileId := ""
+		if profile := props.NetworkProfile; profile != nil && profile.ID != nil {
+			networkProfileId = *profile.ID
+		}
+		d.Set("network_profile_id", networkProfileId)
+
 		d.Set("restart_policy", string(props.RestartPolicy))
 		d.Set("os_type", string(props.OsType))
 
@@ -579,7 +657,7 @@ func resourceArmContainerGroupDelete(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func expandContainerGroupContainers(d *schema.ResourceData) (*[]containerinstance.Container, *[]containerinstance.Port, *[]containerinstance.Volume) {
+func expandContainerGroupContainers(d *schema.ResourceData) (*[]containerinstance.Container, *[]containerinstance.Port, *[]containerinstance.Volume, error) {
 	containersConfig := d.Get("container").([]interface{})
 	containers := make([]containerinstance.Container, 0)
 	containerGroupPorts := make([]containerinstance.Port, 0)
@@ -699,7 +777,10 @@ func expandContainerGroupContainers(d *schema.ResourceData) (*[]containerinstanc
 		}
 
 		if v, ok := data["volume"]; ok {
-			volumeMounts, containerGroupVolumesPartial := expandContainerVolumes(v)
+			volumeMounts, containerGroupVolumesPartial, err := expandContainerVolumes(v)
+			if err != nil {
+				return nil, nil, nil, err
+			}
 			container.VolumeMounts = volumeMounts
 			if containerGroupVolumesPartial != nil {
 				containerGroupVolumes = append(containerGroupVolumes, *containerGroupVolumesPartial...)
@@ -717,7 +798,7 @@ func expandContainerGroupContainers(d *schema.ResourceData) (*[]containerinstanc
 		containers = append(containers, container)
 	}
 
-	return &containers, &containerGroupPorts, &containerGroupVolumes
+	return &containers, &containerGroupPorts, &containerGroupVolumes, nil
 }
 
 func expandContainerEnvironmentVariables(input interface{}, secure bool) *[]containerinstance.EnvironmentVariable {
@@ -796,11 +877,11 @@ func expandContainerImageRegistryCredentials(d *schema.ResourceData) *[]containe
 	return &output
 }
 
-func expandContainerVolumes(input interface{}) (*[]containerinstance.VolumeMount, *[]containerinstance.Volume) {
+func expandContainerVolumes(input interface{}) (*[]containerinstance.VolumeMount, *[]containerinstance.Volume, error) {
 	volumesRaw := input.([]interface{})
 
 	if len(volumesRaw) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	volumeMounts := make([]containerinstance.VolumeMount, 0)
@@ -815,6 +896,8 @@ func expandContainerVolumes(input interface{}) (*[]containerinstance.VolumeMount
 		shareName := volumeConfig["share_name"].(string)
 		storageAccountName := volumeConfig["storage_account_name"].(string)
 		storageAccountKey := volumeConfig["storage_account_key"].(string)
+		secret := volumeConfig["secret"].(map[string]interface{})
+		gitRepo := volumeConfig["git_repo"].([]interface{})
 
 		vm := containerinstance.VolumeMount{
 			Name:      utils.String(name),
@@ -826,18 +909,69 @@ func expandContainerVolumes(input interface{}) (*[]containerinstance.VolumeMount
 
 		cv := containerinstance.Volume{
 			Name: utils.String(name),
-			AzureFile: &containerinstance.AzureFileVolume{
+		}
+
+		isAzureFile := shareName != "" || storageAccountName != "" || storageAccountKey != ""
+		isSecret := len(secret) > 0
+		isGitRepo := len(gitRepo) > 0
+
+		switch {
+		case isAzureFile && !isSecret && !isGitRepo:
+			if shareName == "" || storageAccountName == "" || storageAccountKey == "" {
+				return nil, nil, fmt.Errorf("Error: `share_name`, `storage_account_name` and `storage_account_key` must all be specified for the Azure File volume %q", name)
+			}
+
+			cv.AzureFile = &containerinstance.AzureFileVolume{
 				ShareName:          utils.String(shareName),
 				ReadOnly:           utils.Bool(readOnly),
 				StorageAccountName: utils.String(storageAccountName),
 				StorageAccountKey:  utils.String(storageAccountKey),
-			},
+			}
+
+		case isSecret && !isAzureFile && !isGitRepo:
+			cv.Secret = expandContainerVolumeSecret(secret)
+
+		case isGitRepo && !isAzureFile && !isSecret:
+			cv.GitRepo = expandContainerVolumeGitRepo(gitRepo)
+
+		default:
+			return nil, nil, fmt.Errorf("Error: exactly one of an Azure File share (`share_name`, `storage_account_name` and `storage_account_key`), `secret` or `git_repo` must be specified for the volume %q", name)
 		}
 
 		containerGroupVolumes = append(containerGroupVolumes, cv)
 	}
 
-	return &volumeMounts, &containerGroupVolumes
+	return &volumeMounts, &containerGroupVolumes, nil
+}
+
+func expandContainerVolumeSecret(input map[string]interface{}) map[string]*string {
+	output := make(map[string]*string)
+	for k, v := range input {
+		output[k] = utils.String(v.(string))
+	}
+
+	return output
+}
+
+func expandContainerVolumeGitRepo(input []interface{}) *containerinstance.GitRepoVolume {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+	gitRepo := containerinstance.GitRepoVolume{
+		Repository: utils.String(v["url"].(string)),
+	}
+
+	if directory := v["directory"].(string); directory != "" {
+		gitRepo.Directory = utils.String(directory)
+	}
+
+	if revision := v["revision"].(string); revision != "" {
+		gitRepo.Revision = utils.String(revision)
+	}
+
+	return &gitRepo
 }
 
 func expandContainerProbe(input interface{}) *containerinstance.ContainerProbe {
@@ -1150,6 +1284,10 @@ func flattenContainerVolumes(volumeMounts *[]containerinstance.VolumeMount, cont
 						}
 						// skip storage_account_key, is always nil
 					}
+
+					if gitRepo := cgv.GitRepo; gitRepo != nil {
+						volumeConfig["git_repo"] = flattenContainerVolumeGitRepo(gitRepo)
+					}
 				}
 			}
 		}
@@ -1163,6 +1301,9 @@ func flattenContainerVolumes(volumeMounts *[]containerinstance.VolumeMount, cont
 				if vm.Name != nil && *vm.Name == rawName {
 					storageAccountKey := cv["storage_account_key"].(string)
 					volumeConfig["storage_account_key"] = storageAccountKey
+
+					// the secret values aren't returned from the API
+					volumeConfig["secret"] = cv["secret"]
 				}
 			}
 		}
@@ -1173,6 +1314,35 @@ func flattenContainerVolumes(volumeMounts *[]containerinstance.VolumeMount, cont
 	return volumeConfigs
 }
 
+func flattenContainerVolumeGitRepo(input *containerinstance.GitRepoVolume) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	url := ""
+	if input.Repository != nil {
+		url = *input.Repository
+	}
+
+	directory := ""
+	if input.Directory != nil {
+		directory = *input.Directory
+	}
+
+	revision := ""
+	if input.Revision != nil {
+		revision = *input.Revision
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"url":       url,
+			"directory": directory,
+			"revision":  revision,
+		},
+	}
+}
+
 func flattenContainerProbes(input *containerinstance.ContainerProbe) []interface{} {
 	outputs := make([]interface{}, 0)
 	if input == nil {
@@ -0,0 +1,222 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerregistry/mgmt/2018-09-01/containerregistry"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// resourceArmContainerRegistryImageImport imports an image into a Container Registry. Images aren't an ARM resource,
+// so this can't be imported - and destroying it leaves the imported image in the Container Registry.
+func resourceArmContainerRegistryImageImport() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmContainerRegistryImageImportCreate,
+		Read:   resourceArmContainerRegistryImageImportRead,
+		Delete: resourceArmContainerRegistryImageImportDelete,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"registry_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAzureRMContainerRegistryName,
+			},
+
+			"source_image": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"source_registry_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  azure.ValidateResourceID,
+				ConflictsWith: []string{"source_registry_uri", "source_username", "source_password"},
+			},
+
+			"source_registry_uri": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validate.NoEmptyStrings,
+				ConflictsWith: []string{"source_registry_id"},
+			},
+
+			"source_username": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"source_password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"target_tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+			},
+
+			"untagged_target_repositories": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+			},
+
+			"mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(containerregistry.NoForce),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(containerregistry.Force),
+					string(containerregistry.NoForce),
+				}, false),
+			},
+		},
+	}
+}
+
+func resourceArmContainerRegistryImageImportCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).containers.RegistriesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	registryName := d.Get("registry_name").(string)
+	sourceImage := d.Get("source_image").(string)
+
+	registry, err := client.Get(ctx, resourceGroup, registryName)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Container Registry %q (Resource Group %q): %+v", registryName, resourceGroup, err)
+	}
+
+	if registry.ID == nil {
+		return fmt.Errorf("Error retrieving Container Registry %q (Resource Group %q): `id` was nil", registryName, resourceGroup)
+	}
+
+	source := containerregistry.ImportSource{
+		SourceImage: utils.String(sourceImage),
+	}
+
+	sourceRegistryId := d.Get("source_registry_id").(string)
+	sourceRegistryUri := d.Get("source_registry_uri").(string)
+	if sourceRegistryId == "" && sourceRegistryUri == "" {
+		return fmt.Errorf("Error: one of `source_registry_id` or `source_registry_uri` must be specified")
+	}
+
+	if sourceRegistryId != "" {
+		source.ResourceID = utils.String(sourceRegistryId)
+	}
+
+	if sourceRegistryUri != "" {
+		source.RegistryURI = utils.String(sourceRegistryUri)
+	}
+
+	username := d.Get("source_username").(string)
+	password := d.Get("source_password").(string)
+	if username != "" || password != "" {
+		if password == "" {
+			return fmt.Errorf("Error: `source_password` must be specified when `source_username` is set")
+		}
+
+		source.Credentials = &containerregistry.ImportSourceCredentials{
+			Password: utils.String(password),
+		}
+
+		if username != "" {
+			source.Credentials.Username = utils.String(username)
+		}
+	}
+
+	targetTags := utils.ExpandStringSlice(d.Get("target_tags").([]interface{}))
+	untaggedTargetRepositories := utils.ExpandStringSlice(d.Get("untagged_target_repositories").([]interface{}))
+	parameters := containerregistry.ImportImageParameters{
+		Source:                     &source,
+		TargetTags:                 targetTags,
+		UntaggedTargetRepositories: untaggedTargetRepositories,
+		Mode:                       containerregistry.ImportMode(d.Get("mode").(string)),
+	}
+
+	future, err := client.ImportImage(ctx, resourceGroup, registryName, parameters)
+	if err != nil {
+		return fmt.Errorf("Error importing Image %q into Container Registry %q (Resource Group %q): %+v", sourceImage, registryName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for import of Image %q into Container Registry %q (Resource Group %q): %+v", sourceImage, registryName, resourceGroup, err)
+	}
+
+	// the same image can be imported more than once under different names, so the targets form part of the ID
+	targets := append(*targetTags, *untaggedTargetRepositories...)
+	d.SetId(fmt.Sprintf("%s|%s|%s", *registry.ID, sourceImage, strings.Join(targets, ",")))
+
+	return resourceArmContainerRegistryImageImportRead(d, meta)
+}
+
+func resourceArmContainerRegistryImageImportRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).containers.RegistriesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	splitId := strings.Split(d.Id(), "|")
+	if len(splitId) != 3 {
+		return fmt.Errorf("Expected ID to be in the format {containerRegistryId}|{sourceImage}|{targets} but got %q", d.Id())
+	}
+
+	id, err := parseAzureResourceID(splitId[0])
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	registryName := id.Path["registries"]
+
+	// the imported tags can't be retrieved through the Resource Manager API, so this is create-only and only checks the
+	// Container Registry still exists - meaning changes to the tags made outside of Terraform aren't detected
+	resp, err := client.Get(ctx, resourceGroup, registryName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Container Registry %q was not found in Resource Group %q - removing Image Import from state!", registryName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Container Registry %q (Resource Group %q): %+v", registryName, resourceGroup, err)
+	}
+
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("registry_name", registryName)
+	d.Set("source_image", splitId[1])
+
+	return nil
+}
+
+func resourceArmContainerRegistryImageImportDelete(d *schema.ResourceData, meta interface{}) error {
+	// there's no API to delete an image through the Resource Manager API, so the imported image is left in place
+	log.Printf("[DEBUG] Removing Image Import %q from state - the imported image is retained in the Container Registry", d.Id())
+	return nil
+}
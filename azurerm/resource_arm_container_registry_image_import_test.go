@@ -0,0 +1,136 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+)
+
+func TestAccAzureRMContainerRegistryImageImport_publicRegistry(t *testing.T) {
+	resourceName := "azurerm_container_registry_image_import.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMContainerRegistryImageImport_publicRegistry(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "source_image", "hello-world:latest"),
+					resource.TestCheckResourceAttr(resourceName, "target_tags.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMContainerRegistryImageImport_otherRegistry(t *testing.T) {
+	resourceName := "azurerm_container_registry_image_import.copy"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMContainerRegistryImageImport_otherRegistry(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "mode", "Force"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMContainerRegistryImageImport_multipleTargets(t *testing.T) {
+	resourceName := "azurerm_container_registry_image_import.second"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMContainerRegistryImageImport_multipleTargets(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "target_tags.0", "hello-world:second"),
+					resource.TestCheckResourceAttrPair(resourceName, "source_image", "azurerm_container_registry_image_import.test", "source_image"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAzureRMContainerRegistryImageImport_publicRegistry(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_container_registry" "test" {
+  name                = "acctestacr%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+  sku                 = "Standard"
+}
+
+resource "azurerm_container_registry_image_import" "test" {
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  registry_name       = "${azurerm_container_registry.test.name}"
+  source_registry_uri = "docker.io"
+  source_image        = "hello-world:latest"
+  target_tags         = ["hello-world:latest"]
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMContainerRegistryImageImport_otherRegistry(rInt int, location string) string {
+	template := testAccAzureRMContainerRegistryImageImport_publicRegistry(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry" "other" {
+  name                = "acctestacrother%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+  sku                 = "Standard"
+}
+
+resource "azurerm_container_registry_image_import" "copy" {
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  registry_name       = "${azurerm_container_registry.other.name}"
+  source_registry_id  = "${azurerm_container_registry.test.id}"
+  source_image        = "${azurerm_container_registry_image_import.test.target_tags[0]}"
+  target_tags         = ["hello-world:copied"]
+  mode                = "Force"
+}
+`, template, rInt)
+}
+
+func testAccAzureRMContainerRegistryImageImport_multipleTargets(rInt int, location string) string {
+	template := testAccAzureRMContainerRegistryImageImport_publicRegistry(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_image_import" "second" {
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  registry_name       = "${azurerm_container_registry.test.name}"
+  source_registry_uri = "docker.io"
+  source_image        = "hello-world:latest"
+  target_tags         = ["hello-world:second"]
+}
+`, template)
+}
@@ -0,0 +1,832 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerregistry/mgmt/2018-09-01/containerregistry"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmContainerRegistryTask() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmContainerRegistryTaskCreateUpdate,
+		Read:   resourceArmContainerRegistryTaskRead,
+		Update: resourceArmContainerRegistryTaskCreateUpdate,
+		Delete: resourceArmContainerRegistryTaskDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^[a-zA-Z0-9_-]{5,50}$`),
+					"The Task name must be between 5 and 50 characters in length and can contain only letters, numbers, underscores and dashes.",
+				),
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"registry_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAzureRMContainerRegistryName,
+			},
+
+			"location": azure.SchemaLocation(),
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"timeout_in_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3600,
+				ValidateFunc: validation.IntBetween(300, 28800),
+			},
+
+			"agent_cpu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"platform": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"os": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(containerregistry.Linux),
+								string(containerregistry.Windows),
+							}, false),
+						},
+
+						"architecture": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  string(containerregistry.Amd64),
+							ValidateFunc: validation.StringInSlice([]string{
+								string(containerregistry.Amd64),
+								string(containerregistry.Arm),
+								string(containerregistry.X86),
+							}, false),
+						},
+
+						"variant": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(containerregistry.V6),
+								string(containerregistry.V7),
+								string(containerregistry.V8),
+							}, false),
+						},
+					},
+				},
+			},
+
+			"docker_step": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dockerfile_path": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"context_path": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"context_access_token": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"image_names": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validate.NoEmptyStrings,
+							},
+						},
+
+						"push_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"cache_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"target": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"arguments": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"secret_arguments": {
+							Type:      schema.TypeMap,
+							Optional:  true,
+							Sensitive: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+
+			"source_trigger": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"events": {
+							Type:     schema.TypeSet,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{
+									string(containerregistry.Commit),
+									string(containerregistry.Pullrequest),
+								}, false),
+							},
+						},
+
+						"repository_url": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.URLIsHTTPOrHTTPS,
+						},
+
+						"source_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(containerregistry.Github),
+								string(containerregistry.VisualStudioTeamService),
+							}, false),
+						},
+
+						"branch": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"authentication": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"token_type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(containerregistry.PAT),
+											string(containerregistry.OAuth),
+										}, false),
+									},
+
+									"token": {
+										Type:         schema.TypeString,
+										Required:     true,
+										Sensitive:    true,
+										ValidateFunc: validate.NoEmptyStrings,
+									},
+
+									"refresh_token": {
+										Type:      schema.TypeString,
+										Optional:  true,
+										Sensitive: true,
+									},
+
+									"scope": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+
+									"expire_in_seconds": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"base_image_trigger": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(containerregistry.All),
+								string(containerregistry.Runtime),
+							}, false),
+						},
+
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceArmContainerRegistryTaskCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).containers.TasksClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	registryName := d.Get("registry_name").(string)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, registryName, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing Task %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_container_registry_task", *existing.ID)
+		}
+	}
+
+	location := azure.NormalizeLocation(d.Get("location").(string))
+	tags := d.Get("tags").(map[string]interface{})
+
+	status := containerregistry.TaskStatusDisabled
+	if d.Get("enabled").(bool) {
+		status = containerregistry.TaskStatusEnabled
+	}
+
+	properties := containerregistry.TaskProperties{
+		Status:   status,
+		Platform: expandContainerRegistryTaskPlatform(d.Get("platform").([]interface{})),
+		Timeout:  utils.Int32(int32(d.Get("timeout_in_seconds").(int))),
+		Step:     expandContainerRegistryTaskDockerStep(d.Get("docker_step").([]interface{})),
+		Trigger: &containerregistry.TriggerProperties{
+			SourceTriggers:   expandContainerRegistryTaskSourceTriggers(d.Get("source_trigger").([]interface{})),
+			BaseImageTrigger: expandContainerRegistryTaskBaseImageTrigger(d.Get("base_image_trigger").([]interface{})),
+		},
+	}
+
+	if v, ok := d.GetOk("agent_cpu"); ok {
+		properties.AgentConfiguration = &containerregistry.AgentProperties{
+			CPU: utils.Int32(int32(v.(int))),
+		}
+	}
+
+	parameters := containerregistry.Task{
+		Location:       utils.String(location),
+		TaskProperties: &properties,
+		Tags:           expandTags(tags),
+	}
+
+	// a PUT against an existing Task replaces it, so this handles both Create and Update
+	future, err := client.Create(ctx, resourceGroup, registryName, name, parameters)
+	if err != nil {
+		return fmt.Errorf("Error creating/updating Task %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for creation/update of Task %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, registryName, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Task %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("Error retrieving Task %q (Container Registry %q / Resource Group %q): `id` was nil", name, registryName, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmContainerRegistryTaskRead(d, meta)
+}
+
+func resourceArmContainerRegistryTaskRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).containers.TasksClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	registryName := id.Path["registries"]
+	name := id.Path["tasks"]
+
+	// the secrets (context access token, secret arguments and source control tokens) are only returned from GetDetails
+	resp, err := client.GetDetails(ctx, resourceGroup, registryName, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Task %q was not found in Container Registry %q (Resource Group %q) - removing from state!", name, registryName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Task %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("registry_name", registryName)
+	if location := resp.Location; location != nil {
+		d.Set("location", azure.NormalizeLocation(*location))
+	}
+
+	if props := resp.TaskProperties; props != nil {
+		d.Set("enabled", props.Status == containerregistry.TaskStatusEnabled)
+		d.Set("timeout_in_seconds", props.Timeout)
+
+		agentCPU := 0
+		if agent := props.AgentConfiguration; agent != nil && agent.CPU != nil {
+			agentCPU = int(*agent.CPU)
+		}
+		d.Set("agent_cpu", agentCPU)
+
+		if err := d.Set("platform", flattenContainerRegistryTaskPlatform(props.Platform)); err != nil {
+			return fmt.Errorf("Error setting `platform`: %+v", err)
+		}
+
+		dockerStep, err := flattenContainerRegistryTaskDockerStep(props.Step)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("docker_step", dockerStep); err != nil {
+			return fmt.Errorf("Error setting `docker_step`: %+v", err)
+		}
+
+		var sourceTriggers *[]containerregistry.SourceTrigger
+		var baseImageTrigger *containerregistry.BaseImageTrigger
+		if trigger := props.Trigger; trigger != nil {
+			sourceTriggers = trigger.SourceTriggers
+			baseImageTrigger = trigger.BaseImageTrigger
+		}
+
+		if err := d.Set("source_trigger", flattenContainerRegistryTaskSourceTriggers(sourceTriggers)); err != nil {
+			return fmt.Errorf("Error setting `source_trigger`: %+v", err)
+		}
+
+		if err := d.Set("base_image_trigger", flattenContainerRegistryTaskBaseImageTrigger(baseImageTrigger)); err != nil {
+			return fmt.Errorf("Error setting `base_image_trigger`: %+v", err)
+		}
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmContainerRegistryTaskDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).containers.TasksClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	registryName := id.Path["registries"]
+	name := id.Path["tasks"]
+
+	future, err := client.Delete(ctx, resourceGroup, registryName, name)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Task %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for deletion of Task %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func expandContainerRegistryTaskPlatform(input []interface{}) *containerregistry.PlatformProperties {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+	return &containerregistry.PlatformProperties{
+		Os:           containerregistry.OS(v["os"].(string)),
+		Architecture: containerregistry.Architecture(v["architecture"].(string)),
+		Variant:      containerregistry.Variant(v["variant"].(string)),
+	}
+}
+
+func flattenContainerRegistryTaskPlatform(input *containerregistry.PlatformProperties) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"os":           string(input.Os),
+			"architecture": string(input.Architecture),
+			"variant":      string(input.Variant),
+		},
+	}
+}
+
+func expandContainerRegistryTaskDockerStep(input []interface{}) containerregistry.BasicTaskStepProperties {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+
+	imageNames := make([]string, 0)
+	for _, imageName := range v["image_names"].([]interface{}) {
+		imageNames = append(imageNames, imageName.(string))
+	}
+
+	arguments := make([]containerregistry.Argument, 0)
+	for name, value := range v["arguments"].(map[string]interface{}) {
+		arguments = append(arguments, containerregistry.Argument{
+			Name:     utils.String(name),
+			Value:    utils.String(value.(string)),
+			IsSecret: utils.Bool(false),
+		})
+	}
+	for name, value := range v["secret_arguments"].(map[string]interface{}) {
+		arguments = append(arguments, containerregistry.Argument{
+			Name:     utils.String(name),
+			Value:    utils.String(value.(string)),
+			IsSecret: utils.Bool(true),
+		})
+	}
+
+	step := containerregistry.DockerBuildStep{
+		Type:           containerregistry.TypeDocker,
+		DockerFilePath: utils.String(v["dockerfile_path"].(string)),
+		ContextPath:    utils.String(v["context_path"].(string)),
+		ImageNames:     &imageNames,
+		IsPushEnabled:  utils.Bool(v["push_enabled"].(bool)),
+		NoCache:        utils.Bool(!v["cache_enabled"].(bool)),
+		Arguments:      &arguments,
+	}
+
+	if token := v["context_access_token"].(string); token != "" {
+		step.ContextAccessToken = utils.String(token)
+	}
+
+	if target := v["target"].(string); target != "" {
+		step.Target = utils.String(target)
+	}
+
+	return step
+}
+
+func flattenContainerRegistryTaskDockerStep(input containerregistry.BasicTaskStepProperties) ([]interface{}, error) {
+	if input == nil {
+		return []interface{}{}, nil
+	}
+
+	step, ok := input.AsDockerBuildStep()
+	if !ok || step == nil {
+		return nil, fmt.Errorf("Error flattening `docker_step`: the Task Step is not a Docker Build Step")
+	}
+
+	dockerFilePath := ""
+	if step.DockerFilePath != nil {
+		dockerFilePath = *step.DockerFilePath
+	}
+
+	contextPath := ""
+	if step.ContextPath != nil {
+		contextPath = *step.ContextPath
+	}
+
+	contextAccessToken := ""
+	if step.ContextAccessToken != nil {
+		contextAccessToken = *step.ContextAccessToken
+	}
+
+	imageNames := make([]interface{}, 0)
+	if step.ImageNames != nil {
+		for _, imageName := range *step.ImageNames {
+			imageNames = append(imageNames, imageName)
+		}
+	}
+
+	pushEnabled := false
+	if step.IsPushEnabled != nil {
+		pushEnabled = *step.IsPushEnabled
+	}
+
+	cacheEnabled := true
+	if step.NoCache != nil {
+		cacheEnabled = !*step.NoCache
+	}
+
+	target := ""
+	if step.Target != nil {
+		target = *step.Target
+	}
+
+	arguments := make(map[string]interface{})
+	secretArguments := make(map[string]interface{})
+	if step.Arguments != nil {
+		for _, argument := range *step.Arguments {
+			if argument.Name == nil {
+				continue
+			}
+
+			value := ""
+			if argument.Value != nil {
+				value = *argument.Value
+			}
+
+			if argument.IsSecret != nil && *argument.IsSecret {
+				secretArguments[*argument.Name] = value
+			} else {
+				arguments[*argument.Name] = value
+			}
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"dockerfile_path":      dockerFilePath,
+			"context_path":         contextPath,
+			"context_access_token": contextAccessToken,
+			"image_names":          imageNames,
+			"push_enabled":         pushEnabled,
+			"cache_enabled":        cacheEnabled,
+			"target":               target,
+			"arguments":            arguments,
+			"secret_arguments":     secretArguments,
+		},
+	}, nil
+}
+
+func expandContainerRegistryTaskSourceTriggers(input []interface{}) *[]containerregistry.SourceTrigger {
+	triggers := make([]containerregistry.SourceTrigger, 0)
+
+	for _, raw := range input {
+		v := raw.(map[string]interface{})
+
+		events := make([]containerregistry.SourceTriggerEvent, 0)
+		for _, event := range v["events"].(*schema.Set).List() {
+			events = append(events, containerregistry.SourceTriggerEvent(event.(string)))
+		}
+
+		status := containerregistry.TriggerStatusDisabled
+		if v["enabled"].(bool) {
+			status = containerregistry.TriggerStatusEnabled
+		}
+
+		source := containerregistry.SourceProperties{
+			SourceControlType:           containerregistry.SourceControlType(v["source_type"].(string)),
+			RepositoryURL:               utils.String(v["repository_url"].(string)),
+			SourceControlAuthProperties: expandContainerRegistryTaskSourceTriggerAuthentication(v["authentication"].([]interface{})),
+		}
+
+		if branch := v["branch"].(string); branch != "" {
+			source.Branch = utils.String(branch)
+		}
+
+		triggers = append(triggers, containerregistry.SourceTrigger{
+			Name:                utils.String(v["name"].(string)),
+			SourceTriggerEvents: &events,
+			Status:              status,
+			SourceRepository:    &source,
+		})
+	}
+
+	return &triggers
+}
+
+func flattenContainerRegistryTaskSourceTriggers(input *[]containerregistry.SourceTrigger) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, trigger := range *input {
+		name := ""
+		if trigger.Name != nil {
+			name = *trigger.Name
+		}
+
+		events := make([]interface{}, 0)
+		if trigger.SourceTriggerEvents != nil {
+			for _, event := range *trigger.SourceTriggerEvents {
+				events = append(events, string(event))
+			}
+		}
+
+		repositoryURL := ""
+		sourceType := ""
+		branch := ""
+		authentication := make([]interface{}, 0)
+		if source := trigger.SourceRepository; source != nil {
+			sourceType = string(source.SourceControlType)
+			if source.RepositoryURL != nil {
+				repositoryURL = *source.RepositoryURL
+			}
+			if source.Branch != nil {
+				branch = *source.Branch
+			}
+			authentication = flattenContainerRegistryTaskSourceTriggerAuthentication(source.SourceControlAuthProperties)
+		}
+
+		results = append(results, map[string]interface{}{
+			"name":           name,
+			"events":         schema.NewSet(schema.HashString, events),
+			"repository_url": repositoryURL,
+			"source_type":    sourceType,
+			"branch":         branch,
+			"enabled":        trigger.Status == containerregistry.TriggerStatusEnabled,
+			"authentication": authentication,
+		})
+	}
+
+	return results
+}
+
+func expandContainerRegistryTaskSourceTriggerAuthentication(input []interface{}) *containerregistry.AuthInfo {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+	auth := containerregistry.AuthInfo{
+		TokenType: containerregistry.TokenType(v["token_type"].(string)),
+		Token:     utils.String(v["token"].(string)),
+	}
+
+	if refreshToken := v["refresh_token"].(string); refreshToken != "" {
+		auth.RefreshToken = utils.String(refreshToken)
+	}
+
+	if scope := v["scope"].(string); scope != "" {
+		auth.Scope = utils.String(scope)
+	}
+
+	if expiresIn := v["expire_in_seconds"].(int); expiresIn > 0 {
+		auth.ExpiresIn = utils.Int32(int32(expiresIn))
+	}
+
+	return &auth
+}
+
+func flattenContainerRegistryTaskSourceTriggerAuthentication(input *containerregistry.AuthInfo) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	token := ""
+	if input.Token != nil {
+		token = *input.Token
+	}
+
+	refreshToken := ""
+	if input.RefreshToken != nil {
+		refreshToken = *input.RefreshToken
+	}
+
+	scope := ""
+	if input.Scope != nil {
+		scope = *input.Scope
+	}
+
+	expiresIn := 0
+	if input.ExpiresIn != nil {
+		expiresIn = int(*input.ExpiresIn)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"token_type":        string(input.TokenType),
+			"token":             token,
+			"refresh_token":     refreshToken,
+			"scope":             scope,
+			"expire_in_seconds": expiresIn,
+		},
+	}
+}
+
+func expandContainerRegistryTaskBaseImageTrigger(input []interface{}) *containerregistry.BaseImageTrigger {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+
+	status := containerregistry.TriggerStatusDisabled
+	if v["enabled"].(bool) {
+		status = containerregistry.TriggerStatusEnabled
+	}
+
+	return &containerregistry.BaseImageTrigger{
+		Name:                 utils.String(v["name"].(string)),
+		BaseImageTriggerType: containerregistry.BaseImageTriggerType(v["type"].(string)),
+		Status:               status,
+	}
+}
+
+func flattenContainerRegistryTaskBaseImageTrigger(input *containerregistry.BaseImageTrigger) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	name := ""
+	if input.Name != nil {
+		name = *input.Name
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"name":    name,
+			"type":    string(input.BaseImageTriggerType),
+			"enabled": input.Status == containerregistry.TriggerStatusEnabled,
+		},
+	}
+}
@@ -0,0 +1,298 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAccAzureRMContainerRegistryTask_basic(t *testing.T) {
+	resourceName := "azurerm_container_registry_task.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryTaskDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMContainerRegistryTask_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryTaskExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "platform.0.os", "Linux"),
+					resource.TestCheckResourceAttr(resourceName, "docker_step.0.push_enabled", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMContainerRegistryTask_requiresImport(t *testing.T) {
+	if !requireResourcesToBeImported {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azurerm_container_registry_task.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryTaskDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMContainerRegistryTask_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryTaskExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccAzureRMContainerRegistryTask_requiresImport(ri, location),
+				ExpectError: testRequiresImportError("azurerm_container_registry_task"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMContainerRegistryTask_complete(t *testing.T) {
+	resourceName := "azurerm_container_registry_task.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryTaskDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMContainerRegistryTask_complete(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryTaskExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "timeout_in_seconds", "1800"),
+					resource.TestCheckResourceAttr(resourceName, "docker_step.0.cache_enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "docker_step.0.arguments.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "docker_step.0.secret_arguments.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "base_image_trigger.0.type", "Runtime"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMContainerRegistryTask_update(t *testing.T) {
+	resourceName := "azurerm_container_registry_task.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryTaskDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMContainerRegistryTask_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryTaskExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "base_image_trigger.#", "0"),
+				),
+			},
+			{
+				Config: testAccAzureRMContainerRegistryTask_complete(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryTaskExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "base_image_trigger.#", "1"),
+				),
+			},
+			{
+				Config: testAccAzureRMContainerRegistryTask_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryTaskExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "base_image_trigger.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMContainerRegistryTaskDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).containers.TasksClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_container_registry_task" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		registryName := rs.Primary.Attributes["registry_name"]
+
+		resp, err := client.Get(ctx, resourceGroup, registryName, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(resp.Response) {
+				return err
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("Task %q (Container Registry %q / Resource Group %q) still exists", name, registryName, resourceGroup)
+	}
+
+	return nil
+}
+
+func testCheckAzureRMContainerRegistryTaskExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// Ensure we have enough information in state to look up in API
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		registryName := rs.Primary.Attributes["registry_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).containers.TasksClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, registryName, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Task %q (Container Registry %q / Resource Group %q) does not exist", name, registryName, resourceGroup)
+			}
+
+			return fmt.Errorf("Bad: Get on TasksClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testAccAzureRMContainerRegistryTask_template(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_container_registry" "test" {
+  name                = "acctestacr%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+  sku                 = "Standard"
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMContainerRegistryTask_basic(rInt int, location string) string {
+	template := testAccAzureRMContainerRegistryTask_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_task" "test" {
+  name                = "acctest-task-%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  registry_name       = "${azurerm_container_registry.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+
+  platform {
+    os = "Linux"
+  }
+
+  docker_step {
+    dockerfile_path = "Dockerfile"
+    context_path    = "https://github.com/Azure-Samples/acr-build-helloworld-node"
+    image_names     = ["helloworld:{{.Run.ID}}"]
+  }
+}
+`, template, rInt)
+}
+
+func testAccAzureRMContainerRegistryTask_requiresImport(rInt int, location string) string {
+	template := testAccAzureRMContainerRegistryTask_basic(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_task" "import" {
+  name                = "${azurerm_container_registry_task.test.name}"
+  resource_group_name = "${azurerm_container_registry_task.test.resource_group_name}"
+  registry_name       = "${azurerm_container_registry_task.test.registry_name}"
+  location            = "${azurerm_container_registry_task.test.location}"
+
+  platform {
+    os = "Linux"
+  }
+
+  docker_step {
+    dockerfile_path = "Dockerfile"
+    context_path    = "https://github.com/Azure-Samples/acr-build-helloworld-node"
+    image_names     = ["helloworld:{{.Run.ID}}"]
+  }
+}
+`, template)
+}
+
+func testAccAzureRMContainerRegistryTask_complete(rInt int, location string) string {
+	template := testAccAzureRMContainerRegistryTask_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_task" "test" {
+  name                = "acctest-task-%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  registry_name       = "${azurerm_container_registry.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+  timeout_in_seconds  = 1800
+
+  platform {
+    os           = "Linux"
+    architecture = "amd64"
+  }
+
+  docker_step {
+    dockerfile_path = "Dockerfile"
+    context_path    = "https://github.com/Azure-Samples/acr-build-helloworld-node"
+    image_names     = ["helloworld:{{.Run.ID}}", "helloworld:latest"]
+    cache_enabled   = false
+
+    arguments = {
+      NODE_VERSION = "9"
+    }
+
+    secret_arguments = {
+      NPM_TOKEN = "s3cr3t"
+    }
+  }
+
+  base_image_trigger {
+    name = "baseimage"
+    type = "Runtime"
+  }
+
+  tags = {
+    environment = "Production"
+  }
+}
+`, template, rInt)
+}
@@ -0,0 +1,319 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerregistry/mgmt/2018-09-01/containerregistry"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmContainerRegistryWebhook() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmContainerRegistryWebhookCreate,
+		Read:   resourceArmContainerRegistryWebhookRead,
+		Update: resourceArmContainerRegistryWebhookUpdate,
+		Delete: resourceArmContainerRegistryWebhookDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAzureRMContainerRegistryName,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"registry_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAzureRMContainerRegistryName,
+			},
+
+			"location": azure.SchemaLocation(),
+
+			"service_uri": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.URLIsHTTPOrHTTPS,
+			},
+
+			"actions": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(containerregistry.ChartDelete),
+						string(containerregistry.ChartPush),
+						string(containerregistry.Delete),
+						string(containerregistry.Push),
+						string(containerregistry.Quarantine),
+					}, false),
+				},
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(containerregistry.WebhookStatusEnabled),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(containerregistry.WebhookStatusDisabled),
+					string(containerregistry.WebhookStatusEnabled),
+				}, false),
+			},
+
+			// an empty scope means all events
+			"scope": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+
+			"custom_headers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceArmContainerRegistryWebhookCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).containers.WebhooksClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	registryName := d.Get("registry_name").(string)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, registryName, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing Webhook %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_container_registry_webhook", *existing.ID)
+		}
+	}
+
+	location := azure.NormalizeLocation(d.Get("location").(string))
+	tags := d.Get("tags").(map[string]interface{})
+
+	parameters := containerregistry.WebhookCreateParameters{
+		Location: utils.String(location),
+		WebhookPropertiesCreateParameters: &containerregistry.WebhookPropertiesCreateParameters{
+			ServiceURI:    utils.String(d.Get("service_uri").(string)),
+			CustomHeaders: expandContainerRegistryWebhookCustomHeaders(d.Get("custom_headers").(map[string]interface{})),
+			Actions:       expandContainerRegistryWebhookActions(d.Get("actions").(*schema.Set).List()),
+			Scope:         utils.String(d.Get("scope").(string)),
+			Status:        containerregistry.WebhookStatus(d.Get("status").(string)),
+		},
+		Tags: expandTags(tags),
+	}
+
+	future, err := client.Create(ctx, resourceGroup, registryName, name, parameters)
+	if err != nil {
+		return fmt.Errorf("Error creating Webhook %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for creation of Webhook %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, registryName, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Webhook %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("Error retrieving Webhook %q (Container Registry %q / Resource Group %q): `id` was nil", name, registryName, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmContainerRegistryWebhookRead(d, meta)
+}
+
+func resourceArmContainerRegistryWebhookUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).containers.WebhooksClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	registryName := id.Path["registries"]
+	name := id.Path["webhooks"]
+
+	tags := d.Get("tags").(map[string]interface{})
+
+	parameters := containerregistry.WebhookUpdateParameters{
+		WebhookPropertiesUpdateParameters: &containerregistry.WebhookPropertiesUpdateParameters{
+			ServiceURI:    utils.String(d.Get("service_uri").(string)),
+			CustomHeaders: expandContainerRegistryWebhookCustomHeaders(d.Get("custom_headers").(map[string]interface{})),
+			Actions:       expandContainerRegistryWebhookActions(d.Get("actions").(*schema.Set).List()),
+			Scope:         utils.String(d.Get("scope").(string)),
+			Status:        containerregistry.WebhookStatus(d.Get("status").(string)),
+		},
+		Tags: expandTags(tags),
+	}
+
+	future, err := client.Update(ctx, resourceGroup, registryName, name, parameters)
+	if err != nil {
+		return fmt.Errorf("Error updating Webhook %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for update of Webhook %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+	}
+
+	return resourceArmContainerRegistryWebhookRead(d, meta)
+}
+
+func resourceArmContainerRegistryWebhookRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).containers.WebhooksClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	registryName := id.Path["registries"]
+	name := id.Path["webhooks"]
+
+	resp, err := client.Get(ctx, resourceGroup, registryName, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Webhook %q was not found in Container Registry %q (Resource Group %q) - removing from state!", name, registryName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Webhook %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+	}
+
+	// the Service URI and Custom Headers are only returned from the Callback Config
+	callbackConfig, err := client.GetCallbackConfig(ctx, resourceGroup, registryName, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Callback Config for Webhook %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("registry_name", registryName)
+	if location := resp.Location; location != nil {
+		d.Set("location", azure.NormalizeLocation(*location))
+	}
+
+	d.Set("service_uri", callbackConfig.ServiceURI)
+	if err := d.Set("custom_headers", flattenContainerRegistryWebhookCustomHeaders(callbackConfig.CustomHeaders)); err != nil {
+		return fmt.Errorf("Error setting `custom_headers`: %+v", err)
+	}
+
+	if props := resp.WebhookProperties; props != nil {
+		d.Set("status", string(props.Status))
+		d.Set("scope", props.Scope)
+
+		if err := d.Set("actions", flattenContainerRegistryWebhookActions(props.Actions)); err != nil {
+			return fmt.Errorf("Error setting `actions`: %+v", err)
+		}
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmContainerRegistryWebhookDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).containers.WebhooksClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	registryName := id.Path["registries"]
+	name := id.Path["webhooks"]
+
+	future, err := client.Delete(ctx, resourceGroup, registryName, name)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Webhook %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for deletion of Webhook %q (Container Registry %q / Resource Group %q): %+v", name, registryName, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func expandContainerRegistryWebhookActions(input []interface{}) *[]containerregistry.WebhookAction {
+	actions := make([]containerregistry.WebhookAction, 0)
+	for _, action := range input {
+		actions = append(actions, containerregistry.WebhookAction(action.(string)))
+	}
+
+	return &actions
+}
+
+func flattenContainerRegistryWebhookActions(input *[]containerregistry.WebhookAction) []interface{} {
+	actions := make([]interface{}, 0)
+	if input == nil {
+		return actions
+	}
+
+	for _, action := range *input {
+		actions = append(actions, string(action))
+	}
+
+	return actions
+}
+
+func expandContainerRegistryWebhookCustomHeaders(input map[string]interface{}) map[string]*string {
+	headers := make(map[string]*string)
+	for k, v := range input {
+		headers[k] = utils.String(v.(string))
+	}
+
+	return headers
+}
+
+func flattenContainerRegistryWebhookCustomHeaders(input map[string]*string) map[string]interface{} {
+	headers := make(map[string]interface{})
+	for k, v := range input {
+		if v != nil {
+			headers[k] = *v
+		}
+	}
+
+	return headers
+}
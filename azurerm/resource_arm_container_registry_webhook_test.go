@@ -0,0 +1,274 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAccAzureRMContainerRegistryWebhook_basic(t *testing.T) {
+	resourceName := "azurerm_container_registry_webhook.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryWebhookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMContainerRegistryWebhook_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryWebhookExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "status", "enabled"),
+					resource.TestCheckResourceAttr(resourceName, "actions.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMContainerRegistryWebhook_requiresImport(t *testing.T) {
+	if !requireResourcesToBeImported {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azurerm_container_registry_webhook.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryWebhookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMContainerRegistryWebhook_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryWebhookExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccAzureRMContainerRegistryWebhook_requiresImport(ri, location),
+				ExpectError: testRequiresImportError("azurerm_container_registry_webhook"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMContainerRegistryWebhook_complete(t *testing.T) {
+	resourceName := "azurerm_container_registry_webhook.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryWebhookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMContainerRegistryWebhook_complete(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryWebhookExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "status", "disabled"),
+					resource.TestCheckResourceAttr(resourceName, "scope", "mytag:*"),
+					resource.TestCheckResourceAttr(resourceName, "actions.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "custom_headers.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMContainerRegistryWebhook_update(t *testing.T) {
+	resourceName := "azurerm_container_registry_webhook.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryWebhookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMContainerRegistryWebhook_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryWebhookExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "status", "enabled"),
+					resource.TestCheckResourceAttr(resourceName, "actions.#", "1"),
+				),
+			},
+			{
+				Config: testAccAzureRMContainerRegistryWebhook_complete(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryWebhookExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "status", "disabled"),
+					resource.TestCheckResourceAttr(resourceName, "actions.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "custom_headers.%", "1"),
+				),
+			},
+			{
+				Config: testAccAzureRMContainerRegistryWebhook_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryWebhookExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "status", "enabled"),
+					resource.TestCheckResourceAttr(resourceName, "actions.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "custom_headers.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMContainerRegistryWebhookDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).containers.WebhooksClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_container_registry_webhook" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		registryName := rs.Primary.Attributes["registry_name"]
+
+		resp, err := client.Get(ctx, resourceGroup, registryName, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(resp.Response) {
+				return err
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("Webhook %q (Container Registry %q / Resource Group %q) still exists", name, registryName, resourceGroup)
+	}
+
+	return nil
+}
+
+func testCheckAzureRMContainerRegistryWebhookExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// Ensure we have enough information in state to look up in API
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		registryName := rs.Primary.Attributes["registry_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).containers.WebhooksClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, registryName, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Webhook %q (Container Registry %q / Resource Group %q) does not exist", name, registryName, resourceGroup)
+			}
+
+			return fmt.Errorf("Bad: Get on WebhooksClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testAccAzureRMContainerRegistryWebhook_template(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_container_registry" "test" {
+  name                = "acctestacr%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+  sku                 = "Standard"
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMContainerRegistryWebhook_basic(rInt int, location string) string {
+	template := testAccAzureRMContainerRegistryWebhook_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_webhook" "test" {
+  name                = "testwebhook%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  registry_name       = "${azurerm_container_registry.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+  service_uri         = "https://mywebhookreceiver.example/mytag"
+
+  actions = [
+    "push",
+  ]
+}
+`, template, rInt)
+}
+
+func testAccAzureRMContainerRegistryWebhook_requiresImport(rInt int, location string) string {
+	template := testAccAzureRMContainerRegistryWebhook_basic(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_webhook" "import" {
+  name                = "${azurerm_container_registry_webhook.test.name}"
+  resource_group_name = "${azurerm_container_registry_webhook.test.resource_group_name}"
+  registry_name       = "${azurerm_container_registry_webhook.test.registry_name}"
+  location            = "${azurerm_container_registry_webhook.test.location}"
+  service_uri         = "${azurerm_container_registry_webhook.test.service_uri}"
+  actions             = ["push"]
+}
+`, template)
+}
+
+func testAccAzureRMContainerRegistryWebhook_complete(rInt int, location string) string {
+	template := testAccAzureRMContainerRegistryWebhook_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_webhook" "test" {
+  name                = "testwebhook%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  registry_name       = "${azurerm_container_registry.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+  service_uri         = "https://mywebhookreceiver.example/mytag"
+  status              = "disabled"
+  scope               = "mytag:*"
+
+  actions = [
+    "push",
+    "delete",
+  ]
+
+  custom_headers = {
+    "Content-Type" = "application/json"
+  }
+
+  tags = {
+    environment = "Production"
+  }
+}
+`, template, rInt)
+}
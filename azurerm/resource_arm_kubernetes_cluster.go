@@ -2,6 +2,7 @@ package azurerm
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -18,6 +19,8 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+var kubernetesClusterResourceName = "azurerm_kubernetes_cluster"
+
 func resourceArmKubernetesCluster() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmKubernetesClusterCreateUpdate,
@@ -204,6 +207,12 @@ func resourceArmKubernetesCluster() *schema.Resource {
 							Optional: true,
 							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
+
+						"orchestrator_version": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
 					},
 				},
 			},
@@ -211,7 +220,8 @@ func resourceArmKubernetesCluster() *schema.Resource {
 			// TODO: 2.0 - we should be able to make this a List to be able to detect changes in the Client Secret
 			"service_principal": {
 				Type:     schema.TypeSet,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -234,6 +244,33 @@ func resourceArmKubernetesCluster() *schema.Resource {
 				Set: resourceKubernetesClusterServicePrincipalProfileHash,
 			},
 
+			"identity": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(containerservice.SystemAssigned),
+							}, false),
+						},
+						"principal_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tenant_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
 			// Optional
 			"addon_profile": {
 				Type:     schema.TypeList,
@@ -549,6 +586,64 @@ func resourceArmKubernetesCluster() *schema.Resource {
 				Sensitive: true,
 			},
 
+			"kube_config_exec": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cluster_ca_certificate": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"api_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"command": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"args": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"kube_config_exec_raw": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"kubelet_identity": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"object_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_assigned_identity_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
 			"node_resource_group": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -589,6 +684,9 @@ func resourceArmKubernetesClusterCreateUpdate(d *schema.ResourceData, meta inter
 		}
 	}
 
+	azureRMLockByName(name, kubernetesClusterResourceName)
+	defer azureRMUnlockByName(name, kubernetesClusterResourceName)
+
 	location := azure.NormalizeLocation(d.Get("location").(string))
 	dnsPrefix := d.Get("dns_prefix").(string)
 	kubernetesVersion := d.Get("kubernetes_version").(string)
@@ -599,7 +697,30 @@ func resourceArmKubernetesClusterCreateUpdate(d *schema.ResourceData, meta inter
 		return err
 	}
 
+	if !d.IsNewResource() {
+		// Node Pools managed by the `azurerm_kubernetes_cluster_node_pool` resource need to be sent
+		// as-is, otherwise updating the Kubernetes Cluster would remove them
+		externalProfiles, err := retrieveKubernetesClusterExternalAgentPoolProfiles(d, meta, resGroup, name)
+		if err != nil {
+			return err
+		}
+		agentProfiles = append(agentProfiles, externalProfiles...)
+	}
+
 	servicePrincipalProfile := expandAzureRmKubernetesClusterServicePrincipal(d)
+	identity := expandKubernetesClusterManagedClusterIdentity(d)
+	if identity != nil {
+		// when a Managed Identity is used the Service Principal is reported as `msi`
+		if servicePrincipalProfile == nil {
+			servicePrincipalProfile = &containerservice.ManagedClusterServicePrincipalProfile{
+				ClientID: utils.String("msi"),
+			}
+		} else if servicePrincipalProfile.ClientID == nil || *servicePrincipalProfile.ClientID != "msi" {
+			return fmt.Errorf("A `service_principal` block cannot be specified when an `identity` block is specified")
+		}
+	} else if servicePrincipalProfile == nil {
+		return fmt.Errorf("Either a `service_principal` or an `identity` block must be specified")
+	}
 	networkProfile := expandKubernetesClusterNetworkProfile(d)
 	addonProfiles := expandKubernetesClusterAddonProfiles(d)
 
@@ -620,6 +741,27 @@ func resourceArmKubernetesClusterCreateUpdate(d *schema.ResourceData, meta inter
 	rbacRaw := d.Get("role_based_access_control").([]interface{})
 	rbacEnabled, azureADProfile := expandKubernetesClusterRoleBasedAccessControl(rbacRaw, tenantId)
 
+	if d.IsNewResource() {
+		if err := validateKubernetesClusterVersionsForCreation(ctx, meta, location, kubernetesVersion, agentProfiles); err != nil {
+			return err
+		}
+	} else {
+		if err := validateKubernetesClusterVersionsForUpdate(ctx, d, meta, resGroup, name, location, kubernetesVersion); err != nil {
+			return err
+		}
+
+		// upgrades are orchestrated so that the Control Plane is upgraded first, followed by each Node Pool in turn
+		if d.HasChange("kubernetes_version") {
+			if err := upgradeKubernetesClusterControlPlane(d, meta, resGroup, name, kubernetesVersion, servicePrincipalProfile, azureADProfile); err != nil {
+				return err
+			}
+		}
+
+		if err := upgradeKubernetesClusterAgentPools(d, meta, resGroup, name); err != nil {
+			return err
+		}
+	}
+
 	apiServerAuthorizedIPRangesRaw := d.Get("api_server_authorized_ip_ranges").(*schema.Set).List()
 	apiServerAuthorizedIPRanges := utils.ExpandStringSlice(apiServerAuthorizedIPRangesRaw)
 
@@ -638,7 +780,8 @@ func resourceArmKubernetesClusterCreateUpdate(d *schema.ResourceData, meta inter
 			NetworkProfile:              networkProfile,
 			ServicePrincipalProfile:     servicePrincipalProfile,
 		},
-		Tags: expandTags(tags),
+		Identity: identity,
+		Tags:     expandTags(tags),
 	}
 
 	future, err := client.CreateOrUpdate(ctx, resGroup, name, parameters)
@@ -713,7 +856,15 @@ func resourceArmKubernetesClusterRead(d *schema.ResourceData, meta interface{})
 			return fmt.Errorf("Error setting `addon_profile`: %+v", err)
 		}
 
-		agentPoolProfiles := flattenKubernetesClusterAgentPoolProfiles(props.AgentPoolProfiles, resp.Fqdn)
+		agentPoolProfiles := flattenKubernetesClusterAgentPoolProfiles(filterKubernetesClusterAgentPoolProfiles(d, props.AgentPoolProfiles), resp.Fqdn)
+		// Node Pools without an `orchestrator_version` follow the `kubernetes_version`, so it's only tracked when it's set
+		orchestratorVersions := kubernetesClusterAgentPoolProfileOrchestratorVersions(d.Get("agent_pool_profile").([]interface{}))
+		for _, raw := range agentPoolProfiles {
+			profile := raw.(map[string]interface{})
+			if name, ok := profile["name"].(string); !ok || orchestratorVersions[name] == "" {
+				delete(profile, "orchestrator_version")
+			}
+		}
 		if err := d.Set("agent_pool_profile", agentPoolProfiles); err != nil {
 			return fmt.Errorf("Error setting `agent_pool_profile`: %+v", err)
 		}
@@ -756,12 +907,27 @@ func resourceArmKubernetesClusterRead(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if err := d.Set("identity", flattenKubernetesClusterManagedClusterIdentity(resp.Identity)); err != nil {
+		return fmt.Errorf("Error setting `identity`: %+v", err)
+	}
+
+	kubeletIdentity := retrieveKubernetesClusterKubeletIdentity(meta, resp)
+	if err := d.Set("kubelet_identity", kubeletIdentity); err != nil {
+		return fmt.Errorf("Error setting `kubelet_identity`: %+v", err)
+	}
+
 	kubeConfigRaw, kubeConfig := flattenKubernetesClusterAccessProfile(profile)
 	d.Set("kube_config_raw", kubeConfigRaw)
 	if err := d.Set("kube_config", kubeConfig); err != nil {
 		return fmt.Errorf("Error setting `kube_config`: %+v", err)
 	}
 
+	kubeConfigExecRaw, kubeConfigExec := flattenKubernetesClusterAccessProfileExec(meta, profile)
+	d.Set("kube_config_exec_raw", kubeConfigExecRaw)
+	if err := d.Set("kube_config_exec", kubeConfigExec); err != nil {
+		return fmt.Errorf("Error setting `kube_config_exec`: %+v", err)
+	}
+
 	flattenAndSetTags(d, resp.Tags)
 
 	return nil
@@ -778,6 +944,9 @@ func resourceArmKubernetesClusterDelete(d *schema.ResourceData, meta interface{}
 	resGroup := id.ResourceGroup
 	name := id.Path["managedClusters"]
 
+	azureRMLockByName(name, kubernetesClusterResourceName)
+	defer azureRMUnlockByName(name, kubernetesClusterResourceName)
+
 	future, err := client.Delete(ctx, resGroup, name)
 	if err != nil {
 		return fmt.Errorf("Error deleting Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resGroup, err)
@@ -818,6 +987,48 @@ func flattenKubernetesClusterAccessProfile(profile containerservice.ManagedClust
 	return nil, []interface{}{}
 }
 
+// flattenKubernetesClusterAccessProfileExec returns a kubeconfig using the `kubelogin` exec credential plugin
+// when the Access Profile uses Azure Active Directory
+func flattenKubernetesClusterAccessProfileExec(meta interface{}, profile containerservice.ManagedClusterAccessProfile) (*string, []interface{}) {
+	accessProfile := profile.AccessProfile
+	if accessProfile == nil || accessProfile.KubeConfig == nil {
+		return nil, []interface{}{}
+	}
+
+	rawConfig := string(*accessProfile.KubeConfig)
+	if !strings.Contains(rawConfig, "apiserver-id:") {
+		return nil, []interface{}{}
+	}
+
+	kubeConfigAAD, err := kubernetes.ParseKubeConfigAAD(rawConfig)
+	if err != nil {
+		log.Printf("[WARN] Unable to parse the Azure Active Directory kubeconfig: %+v", err)
+		return nil, []interface{}{}
+	}
+
+	environment := meta.(*ArmClient).environment.Name
+	kubeConfigExec, rawExecConfig, err := kubernetes.BuildKubeConfigExec(*kubeConfigAAD, environment)
+	if err != nil {
+		log.Printf("[WARN] Unable to build the exec kubeconfig: %+v", err)
+		return nil, []interface{}{}
+	}
+
+	// we don't size-check these since they're validated in the Parse/Build methods
+	cluster := kubeConfigExec.Clusters[0].Cluster
+	exec := kubeConfigExec.Users[0].User.Exec
+	flattened := []interface{}{
+		map[string]interface{}{
+			"host":                   cluster.Server,
+			"cluster_ca_certificate": cluster.ClusterAuthorityData,
+			"api_version":            exec.APIVersion,
+			"command":                exec.Command,
+			"args":                   utils.FlattenStringSlice(&exec.Args),
+		},
+	}
+
+	return utils.String(rawExecConfig), flattened
+}
+
 func expandKubernetesClusterAddonProfiles(d *schema.ResourceData) map[string]*containerservice.ManagedClusterAddonProfile {
 	profiles := d.Get("addon_profile").([]interface{})
 	if len(profiles) == 0 {
@@ -1000,12 +1211,338 @@ func expandKubernetesClusterAgentPoolProfiles(d *schema.ResourceData) ([]contain
 			profile.NodeTaints = nodeTaints
 		}
 
+		// the version of each Node Pool can only be set independently when using Virtual Machine Scale Sets,
+		// Availability Set based Node Pools are upgraded alongside the Control Plane
+		if orchestratorVersion := config["orchestrator_version"].(string); orchestratorVersion != "" && profile.Type == containerservice.VirtualMachineScaleSets {
+			profile.OrchestratorVersion = utils.String(orchestratorVersion)
+		}
+
 		profiles = append(profiles, profile)
 	}
 
 	return profiles, nil
 }
 
+func validateKubernetesClusterVersionsForCreation(ctx context.Context, meta interface{}, location string, kubernetesVersion string, profiles []containerservice.ManagedClusterAgentPoolProfile) error {
+	if kubernetesVersion == "" {
+		return nil
+	}
+
+	if err := validateKubernetesVersionIsAvailable(ctx, meta, location, kubernetesVersion); err != nil {
+		return err
+	}
+
+	for _, profile := range profiles {
+		if profile.OrchestratorVersion == nil {
+			continue
+		}
+
+		if err := validateKubernetesNodePoolVersion(kubernetesVersion, *profile.OrchestratorVersion); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upgradeKubernetesClusterControlPlane upgrades the Control Plane of the Kubernetes Cluster, along with the Node Pools
+// in the `agent_pool_profile` block which don't set an `orchestrator_version`. All other Virtual Machine Scale Set
+// based Node Pools are pinned to the version they're currently running
+func upgradeKubernetesClusterControlPlane(d *schema.ResourceData, meta interface{}, resourceGroup, name, kubernetesVersion string, servicePrincipalProfile *containerservice.ManagedClusterServicePrincipalProfile, aadProfile *containerservice.ManagedClusterAADProfile) error {
+	client := meta.(*ArmClient).containers.KubernetesClustersClient
+	ctx := meta.(*ArmClient).StopContext
+
+	existing, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	props := existing.ManagedClusterProperties
+	if props == nil {
+		return fmt.Errorf("Error retrieving Managed Kubernetes Cluster %q (Resource Group %q): `properties` was nil", name, resourceGroup)
+	}
+
+	if props.AgentPoolProfiles != nil {
+		orchestratorVersions := kubernetesClusterAgentPoolProfileOrchestratorVersions(d.Get("agent_pool_profile").([]interface{}))
+		profiles := *props.AgentPoolProfiles
+		for i, profile := range profiles {
+			if profile.Type != containerservice.VirtualMachineScaleSets {
+				continue
+			}
+
+			if profile.Name != nil {
+				if version, tracked := orchestratorVersions[*profile.Name]; tracked && version == "" {
+					profiles[i].OrchestratorVersion = utils.String(kubernetesVersion)
+					continue
+				}
+			}
+
+			if profile.OrchestratorVersion == nil {
+				profiles[i].OrchestratorVersion = props.KubernetesVersion
+			}
+		}
+		props.AgentPoolProfiles = &profiles
+	}
+
+	// the secrets aren't returned from the API, so these need to be sent from the configuration
+	props.ServicePrincipalProfile = servicePrincipalProfile
+	props.AadProfile = aadProfile
+	props.KubernetesVersion = utils.String(kubernetesVersion)
+
+	log.Printf("[DEBUG] Upgrading the Control Plane of Managed Kubernetes Cluster %q (Resource Group %q) to %q..", name, resourceGroup, kubernetesVersion)
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, name, existing)
+	if err != nil {
+		return fmt.Errorf("Error upgrading the Control Plane of Managed Kubernetes Cluster %q (Resource Group %q) to %q: %+v", name, resourceGroup, kubernetesVersion, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for the Control Plane of Managed Kubernetes Cluster %q (Resource Group %q) to be upgraded to %q: %+v", name, resourceGroup, kubernetesVersion, err)
+	}
+
+	return nil
+}
+
+// validateKubernetesClusterVersionsForUpdate validates the Control Plane and Node Pool versions against the upgrades which
+// Azure offers before anything is upgraded, so that an invalid version doesn't leave an upgrade partially applied
+func validateKubernetesClusterVersionsForUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, resourceGroup, name, location, kubernetesVersion string) error {
+	client := meta.(*ArmClient).containers.KubernetesClustersClient
+
+	nodePoolUpgrades := kubernetesClusterNodePoolUpgrades(d)
+	if !d.HasChange("kubernetes_version") && len(nodePoolUpgrades) == 0 {
+		return nil
+	}
+
+	controlPlaneUpgradeVersions, nodePoolUpgradeVersions, err := listKubernetesClusterUpgradeVersions(ctx, client, resourceGroup, name)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("kubernetes_version") {
+		oldVersion, _ := d.GetChange("kubernetes_version")
+		currentVersion := oldVersion.(string)
+
+		if err := validateKubernetesVersionIsAvailable(ctx, meta, location, kubernetesVersion); err != nil {
+			return err
+		}
+		if err := validateKubernetesVersionUpgrade(currentVersion, kubernetesVersion); err != nil {
+			return err
+		}
+		if err := validateKubernetesVersionIsUpgradable(currentVersion, kubernetesVersion, controlPlaneUpgradeVersions); err != nil {
+			return err
+		}
+	}
+
+	for _, upgrade := range nodePoolUpgrades {
+		if err := validateKubernetesNodePoolVersion(kubernetesVersion, upgrade.targetVersion); err != nil {
+			return fmt.Errorf("Error validating `orchestrator_version` for Node Pool %q: %+v", upgrade.name, err)
+		}
+		if err := validateKubernetesVersionIsAvailable(ctx, meta, location, upgrade.targetVersion); err != nil {
+			return fmt.Errorf("Error validating `orchestrator_version` for Node Pool %q: %+v", upgrade.name, err)
+		}
+
+		// the version the Node Pool was running isn't tracked when it followed the `kubernetes_version`
+		if upgrade.currentVersion == "" {
+			continue
+		}
+
+		if err := validateKubernetesVersionUpgrade(upgrade.currentVersion, upgrade.targetVersion); err != nil {
+			return fmt.Errorf("Error validating `orchestrator_version` for Node Pool %q: %+v", upgrade.name, err)
+		}
+
+		// the Node Pool upgrades are limited by the current version of the Control Plane - which is upgraded first
+		upgradeVersions := append([]string{}, nodePoolUpgradeVersions[upgrade.name]...)
+		if d.HasChange("kubernetes_version") {
+			upgradeVersions = append(upgradeVersions, controlPlaneUpgradeVersions...)
+		}
+		if err := validateKubernetesVersionIsUpgradable(upgrade.currentVersion, upgrade.targetVersion, upgradeVersions); err != nil {
+			return fmt.Errorf("Error validating `orchestrator_version` for Node Pool %q: %+v", upgrade.name, err)
+		}
+	}
+
+	return nil
+}
+
+type kubernetesClusterNodePoolUpgrade struct {
+	name           string
+	currentVersion string
+	targetVersion  string
+}
+
+// kubernetesClusterNodePoolUpgrades returns the Node Pools in the `agent_pool_profile` block whose
+// `orchestrator_version` has changed
+func kubernetesClusterNodePoolUpgrades(d *schema.ResourceData) []kubernetesClusterNodePoolUpgrade {
+	oldRaw, newRaw := d.GetChange("agent_pool_profile")
+	oldVersions := make(map[string]string)
+	for _, raw := range oldRaw.([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		profile := raw.(map[string]interface{})
+		oldVersions[profile["name"].(string)] = profile["orchestrator_version"].(string)
+	}
+
+	upgrades := make([]kubernetesClusterNodePoolUpgrade, 0)
+	for _, raw := range newRaw.([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		profile := raw.(map[string]interface{})
+		poolName := profile["name"].(string)
+		poolType := profile["type"].(string)
+		targetVersion := profile["orchestrator_version"].(string)
+
+		currentVersion, exists := oldVersions[poolName]
+		if !exists || poolType != string(containerservice.VirtualMachineScaleSets) || targetVersion == "" || targetVersion == currentVersion {
+			continue
+		}
+
+		upgrades = append(upgrades, kubernetesClusterNodePoolUpgrade{
+			name:           poolName,
+			currentVersion: currentVersion,
+			targetVersion:  targetVersion,
+		})
+	}
+
+	return upgrades
+}
+
+// upgradeKubernetesClusterAgentPools upgrades each Node Pool in the `agent_pool_profile` block whose
+// `orchestrator_version` has changed, one at a time - these versions are validated beforehand
+func upgradeKubernetesClusterAgentPools(d *schema.ResourceData, meta interface{}, resourceGroup, name string) error {
+	client := meta.(*ArmClient).containers.AgentPoolsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	for _, upgrade := range kubernetesClusterNodePoolUpgrades(d) {
+		poolName := upgrade.name
+		targetVersion := upgrade.targetVersion
+
+		pool, err := client.Get(ctx, resourceGroup, name, poolName)
+		if err != nil {
+			return fmt.Errorf("Error retrieving Node Pool %q (Managed Kubernetes Cluster %q / Resource Group %q): %+v", poolName, name, resourceGroup, err)
+		}
+		if pool.ManagedClusterAgentPoolProfileProperties == nil {
+			return fmt.Errorf("Error retrieving Node Pool %q (Managed Kubernetes Cluster %q / Resource Group %q): `properties` was nil", poolName, name, resourceGroup)
+		}
+		pool.ManagedClusterAgentPoolProfileProperties.OrchestratorVersion = utils.String(targetVersion)
+
+		log.Printf("[DEBUG] Upgrading Node Pool %q (Managed Kubernetes Cluster %q / Resource Group %q) to %q..", poolName, name, resourceGroup, targetVersion)
+		future, err := client.CreateOrUpdate(ctx, resourceGroup, name, poolName, pool)
+		if err != nil {
+			return fmt.Errorf("Error upgrading Node Pool %q (Managed Kubernetes Cluster %q / Resource Group %q) to %q: %+v", poolName, name, resourceGroup, targetVersion, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for Node Pool %q (Managed Kubernetes Cluster %q / Resource Group %q) to be upgraded to %q: %+v", poolName, name, resourceGroup, targetVersion, err)
+		}
+	}
+
+	return nil
+}
+
+// filterKubernetesClusterAgentPoolProfiles returns the Agent Pool Profiles tracked in the `agent_pool_profile` block,
+// so that Node Pools managed by the `azurerm_kubernetes_cluster_node_pool` resource don't show up as a diff.
+// When nothing is tracked yet (e.g. during an import) all of the Agent Pool Profiles are returned.
+func filterKubernetesClusterAgentPoolProfiles(d *schema.ResourceData, profiles *[]containerservice.ManagedClusterAgentPoolProfile) *[]containerservice.ManagedClusterAgentPoolProfile {
+	if profiles == nil {
+		return nil
+	}
+
+	trackedNames := kubernetesClusterAgentPoolProfileNames(d.Get("agent_pool_profile").([]interface{}))
+	if len(trackedNames) == 0 {
+		return profiles
+	}
+
+	filtered := make([]containerservice.ManagedClusterAgentPoolProfile, 0)
+	for _, profile := range *profiles {
+		if profile.Name == nil {
+			continue
+		}
+
+		if _, ok := trackedNames[*profile.Name]; ok {
+			filtered = append(filtered, profile)
+		}
+	}
+
+	return &filtered
+}
+
+// retrieveKubernetesClusterExternalAgentPoolProfiles returns the Agent Pools which exist on the Kubernetes Cluster
+// but have never been tracked in the `agent_pool_profile` block
+func retrieveKubernetesClusterExternalAgentPoolProfiles(d *schema.ResourceData, meta interface{}, resourceGroup, name string) ([]containerservice.ManagedClusterAgentPoolProfile, error) {
+	client := meta.(*ArmClient).containers.AgentPoolsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	oldRaw, newRaw := d.GetChange("agent_pool_profile")
+	oldNames := kubernetesClusterAgentPoolProfileNames(oldRaw.([]interface{}))
+	newNames := kubernetesClusterAgentPoolProfileNames(newRaw.([]interface{}))
+
+	profiles := make([]containerservice.ManagedClusterAgentPoolProfile, 0)
+	iterator, err := client.ListComplete(ctx, resourceGroup, name)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing Node Pools for Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	for iterator.NotDone() {
+		pool := iterator.Value()
+		if pool.Name != nil && pool.ManagedClusterAgentPoolProfileProperties != nil {
+			_, isOld := oldNames[*pool.Name]
+			_, isNew := newNames[*pool.Name]
+			if !isOld && !isNew {
+				props := pool.ManagedClusterAgentPoolProfileProperties
+				profiles = append(profiles, containerservice.ManagedClusterAgentPoolProfile{
+					Name:                   pool.Name,
+					Count:                  props.Count,
+					VMSize:                 props.VMSize,
+					OsDiskSizeGB:           props.OsDiskSizeGB,
+					VnetSubnetID:           props.VnetSubnetID,
+					MaxPods:                props.MaxPods,
+					OsType:                 props.OsType,
+					MaxCount:               props.MaxCount,
+					MinCount:               props.MinCount,
+					EnableAutoScaling:      props.EnableAutoScaling,
+					Type:                   props.Type,
+					OrchestratorVersion:    props.OrchestratorVersion,
+					AvailabilityZones:      props.AvailabilityZones,
+					EnableNodePublicIP:     props.EnableNodePublicIP,
+					ScaleSetPriority:       props.ScaleSetPriority,
+					ScaleSetEvictionPolicy: props.ScaleSetEvictionPolicy,
+					NodeTaints:             props.NodeTaints,
+				})
+			}
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("Error listing Node Pools for Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
+	return profiles, nil
+}
+
+// kubernetesClusterAgentPoolProfileOrchestratorVersions returns the `orchestrator_version` of each Node Pool in the
+// `agent_pool_profile` block, keyed by name - which is empty when the Node Pool follows the `kubernetes_version`
+func kubernetesClusterAgentPoolProfileOrchestratorVersions(input []interface{}) map[string]string {
+	versions := make(map[string]string)
+	for _, raw := range input {
+		if raw == nil {
+			continue
+		}
+		profile := raw.(map[string]interface{})
+		versions[profile["name"].(string)] = profile["orchestrator_version"].(string)
+	}
+	return versions
+}
+
+func kubernetesClusterAgentPoolProfileNames(input []interface{}) map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, raw := range input {
+		if raw == nil {
+			continue
+		}
+		profile := raw.(map[string]interface{})
+		names[profile["name"].(string)] = struct{}{}
+	}
+	return names
+}
+
 func flattenKubernetesClusterAgentPoolProfiles(profiles *[]containerservice.ManagedClusterAgentPoolProfile, fqdn *string) []interface{} {
 	if profiles == nil {
 		return []interface{}{}
@@ -1071,6 +1608,10 @@ func flattenKubernetesClusterAgentPoolProfiles(profiles *[]containerservice.Mana
 			agentPoolProfile["node_taints"] = *profile.NodeTaints
 		}
 
+		if profile.OrchestratorVersion != nil {
+			agentPoolProfile["orchestrator_version"] = *profile.OrchestratorVersion
+		}
+
 		agentPoolProfiles = append(agentPoolProfiles, agentPoolProfile)
 	}
 
@@ -1308,12 +1849,106 @@ func expandAzureRmKubernetesClusterServicePrincipal(d *schema.ResourceData) *con
 
 	principal := containerservice.ManagedClusterServicePrincipalProfile{
 		ClientID: &clientId,
-		Secret:   &clientSecret,
+	}
+
+	// there's no secret when a Managed Identity is used
+	if clientId != "msi" {
+		principal.Secret = &clientSecret
 	}
 
 	return &principal
 }
 
+func expandKubernetesClusterManagedClusterIdentity(d *schema.ResourceData) *containerservice.ManagedClusterIdentity {
+	identities := d.Get("identity").([]interface{})
+	if len(identities) == 0 || identities[0] == nil {
+		return nil
+	}
+
+	identity := identities[0].(map[string]interface{})
+	return &containerservice.ManagedClusterIdentity{
+		Type: containerservice.ResourceIdentityType(identity["type"].(string)),
+	}
+}
+
+func flattenKubernetesClusterManagedClusterIdentity(identity *containerservice.ManagedClusterIdentity) []interface{} {
+	if identity == nil || identity.Type == containerservice.None {
+		return []interface{}{}
+	}
+
+	principalId := ""
+	if identity.PrincipalID != nil {
+		principalId = *identity.PrincipalID
+	}
+
+	tenantId := ""
+	if identity.TenantID != nil {
+		tenantId = *identity.TenantID
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type":         string(identity.Type),
+			"principal_id": principalId,
+			"tenant_id":    tenantId,
+		},
+	}
+}
+
+// retrieveKubernetesClusterKubeletIdentity returns the User Assigned Identity used by the Nodes when the Kubernetes
+// Cluster uses a System Assigned Identity. This isn't returned by the API, but is created by AKS within the
+// Node Resource Group using the name `{clusterName}-agentpool` - as such when it can't be retrieved (for example
+// due to permissions) a warning is logged and the Kubelet Identity is left empty, rather than failing the Read.
+func retrieveKubernetesClusterKubeletIdentity(meta interface{}, cluster containerservice.ManagedCluster) []interface{} {
+	client := meta.(*ArmClient).msi.UserAssignedIdentitiesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	if cluster.Identity == nil || cluster.Identity.Type != containerservice.SystemAssigned {
+		return []interface{}{}
+	}
+	if cluster.Name == nil || cluster.ManagedClusterProperties == nil || cluster.ManagedClusterProperties.NodeResourceGroup == nil {
+		return []interface{}{}
+	}
+
+	resourceGroup := *cluster.ManagedClusterProperties.NodeResourceGroup
+	name := fmt.Sprintf("%s-agentpool", *cluster.Name)
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Kubelet Identity %q was not found in Resource Group %q", name, resourceGroup)
+			return []interface{}{}
+		}
+
+		log.Printf("[WARN] Error retrieving Kubelet Identity %q (Resource Group %q) - leaving `kubelet_identity` empty: %+v", name, resourceGroup, err)
+		return []interface{}{}
+	}
+
+	clientId := ""
+	objectId := ""
+	if props := resp.IdentityProperties; props != nil {
+		if props.ClientID != nil {
+			clientId = props.ClientID.String()
+		}
+		if props.PrincipalID != nil {
+			objectId = props.PrincipalID.String()
+		}
+	}
+
+	userAssignedIdentityId := ""
+	if resp.ID != nil {
+		userAssignedIdentityId = *resp.ID
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"client_id":                 clientId,
+			"object_id":                 objectId,
+			"user_assigned_identity_id": userAssignedIdentityId,
+		},
+	}
+}
+
 func flattenAzureRmKubernetesClusterServicePrincipalProfile(profile *containerservice.ManagedClusterServicePrincipalProfile) *schema.Set {
 	if profile == nil {
 		return nil
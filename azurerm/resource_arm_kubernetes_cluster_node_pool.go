@@ -0,0 +1,424 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2019-06-01/containerservice"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmKubernetesClusterNodePool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmKubernetesClusterNodePoolCreateUpdate,
+		Read:   resourceArmKubernetesClusterNodePoolRead,
+		Update: resourceArmKubernetesClusterNodePoolCreateUpdate,
+		Delete: resourceArmKubernetesClusterNodePoolDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.KubernetesAgentPoolName,
+			},
+
+			"kubernetes_cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"vm_size": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppress.CaseDifference,
+				ValidateFunc:     validate.NoEmptyStrings,
+			},
+
+			"node_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+
+			"enable_auto_scaling": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"min_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+
+			"max_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+
+			"availability_zones": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"node_taints": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"orchestrator_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"os_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(containerservice.Linux),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(containerservice.Linux),
+					string(containerservice.Windows),
+				}, true),
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+
+			"os_disk_size_gb": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"max_pods": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"vnet_subnet_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+		},
+	}
+}
+
+func resourceArmKubernetesClusterNodePoolCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	clustersClient := meta.(*ArmClient).containers.KubernetesClustersClient
+	client := meta.(*ArmClient).containers.AgentPoolsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	kubernetesClusterId, err := parseAzureResourceID(d.Get("kubernetes_cluster_id").(string))
+	if err != nil {
+		return fmt.Errorf("Error parsing Kubernetes Cluster ID %q: %+v", d.Get("kubernetes_cluster_id").(string), err)
+	}
+	resourceGroup := kubernetesClusterId.ResourceGroup
+	clusterName := kubernetesClusterId.Path["managedClusters"]
+
+	// Node Pools within the same Kubernetes Cluster can't be modified concurrently
+	azureRMLockByName(clusterName, kubernetesClusterResourceName)
+	defer azureRMUnlockByName(clusterName, kubernetesClusterResourceName)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, clusterName, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_kubernetes_cluster_node_pool", *existing.ID)
+		}
+	}
+
+	cluster, err := clustersClient.Get(ctx, resourceGroup, clusterName)
+	if err != nil {
+		if utils.ResponseWasNotFound(cluster.Response) {
+			return fmt.Errorf("Kubernetes Cluster %q was not found in Resource Group %q", clusterName, resourceGroup)
+		}
+
+		return fmt.Errorf("Error retrieving Kubernetes Cluster %q (Resource Group %q): %+v", clusterName, resourceGroup, err)
+	}
+
+	// multiple Node Pools are only supported when the Kubernetes Cluster is backed by Virtual Machine Scale Sets
+	if props := cluster.ManagedClusterProperties; props != nil && props.AgentPoolProfiles != nil {
+		for _, profile := range *props.AgentPoolProfiles {
+			if profile.Type != containerservice.VirtualMachineScaleSets {
+				return fmt.Errorf("Node Pools can only be added to Kubernetes Clusters whose `agent_pool_profile` blocks use the type %q", string(containerservice.VirtualMachineScaleSets))
+			}
+		}
+	}
+
+	if d.HasChange("orchestrator_version") {
+		if err := validateKubernetesClusterNodePoolVersion(d, meta, cluster); err != nil {
+			return err
+		}
+	}
+
+	enableAutoScaling := d.Get("enable_auto_scaling").(bool)
+	profile := containerservice.ManagedClusterAgentPoolProfileProperties{
+		Type:              containerservice.VirtualMachineScaleSets,
+		VMSize:            containerservice.VMSizeTypes(d.Get("vm_size").(string)),
+		OsType:            containerservice.OSType(d.Get("os_type").(string)),
+		EnableAutoScaling: utils.Bool(enableAutoScaling),
+	}
+
+	if count := d.Get("node_count").(int); count > 0 {
+		// Auto scaling will change the number of nodes, so the original count should only be sent on creation
+		if !enableAutoScaling || d.IsNewResource() {
+			profile.Count = utils.Int32(int32(count))
+		}
+	} else if d.IsNewResource() {
+		profile.Count = utils.Int32(int32(1))
+	}
+
+	if enableAutoScaling {
+		minCount := d.Get("min_count").(int)
+		maxCount := d.Get("max_count").(int)
+		if minCount == 0 || maxCount == 0 {
+			return fmt.Errorf("`min_count` and `max_count` must be set when `enable_auto_scaling` is enabled")
+		}
+		if minCount > maxCount {
+			return fmt.Errorf("`min_count` must be less than or equal to `max_count`")
+		}
+
+		profile.MinCount = utils.Int32(int32(minCount))
+		profile.MaxCount = utils.Int32(int32(maxCount))
+	}
+
+	if availabilityZones := utils.ExpandStringSlice(d.Get("availability_zones").([]interface{})); len(*availabilityZones) > 0 {
+		profile.AvailabilityZones = availabilityZones
+	}
+
+	if nodeTaints := utils.ExpandStringSlice(d.Get("node_taints").([]interface{})); len(*nodeTaints) > 0 {
+		profile.NodeTaints = nodeTaints
+	}
+
+	if osDiskSizeGB := d.Get("os_disk_size_gb").(int); osDiskSizeGB > 0 {
+		profile.OsDiskSizeGB = utils.Int32(int32(osDiskSizeGB))
+	}
+
+	if maxPods := d.Get("max_pods").(int); maxPods > 0 {
+		profile.MaxPods = utils.Int32(int32(maxPods))
+	}
+
+	if vnetSubnetID := d.Get("vnet_subnet_id").(string); vnetSubnetID != "" {
+		profile.VnetSubnetID = utils.String(vnetSubnetID)
+	}
+
+	if orchestratorVersion := d.Get("orchestrator_version").(string); orchestratorVersion != "" {
+		profile.OrchestratorVersion = utils.String(orchestratorVersion)
+	}
+
+	parameters := containerservice.AgentPool{
+		Name:                                     utils.String(name),
+		ManagedClusterAgentPoolProfileProperties: &profile,
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, clusterName, name, parameters)
+	if err != nil {
+		return fmt.Errorf("Error creating/updating Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for creation/update of Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, clusterName, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("Error retrieving Node Pool %q (Kubernetes Cluster %q / Resource Group %q): `id` was nil", name, clusterName, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmKubernetesClusterNodePoolRead(d, meta)
+}
+
+func resourceArmKubernetesClusterNodePoolRead(d *schema.ResourceData, meta interface{}) error {
+	clustersClient := meta.(*ArmClient).containers.KubernetesClustersClient
+	client := meta.(*ArmClient).containers.AgentPoolsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	clusterName := id.Path["managedClusters"]
+	name := id.Path["agentPools"]
+
+	cluster, err := clustersClient.Get(ctx, resourceGroup, clusterName)
+	if err != nil {
+		if utils.ResponseWasNotFound(cluster.Response) {
+			log.Printf("[DEBUG] Kubernetes Cluster %q was not found in Resource Group %q - removing Node Pool from state!", clusterName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Kubernetes Cluster %q (Resource Group %q): %+v", clusterName, resourceGroup, err)
+	}
+
+	resp, err := client.Get(ctx, resourceGroup, clusterName, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Node Pool %q was not found in Kubernetes Cluster %q (Resource Group %q) - removing from state!", name, clusterName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+	}
+
+	d.Set("name", name)
+	d.Set("kubernetes_cluster_id", cluster.ID)
+
+	if props := resp.ManagedClusterAgentPoolProfileProperties; props != nil {
+		d.Set("vm_size", string(props.VMSize))
+		d.Set("os_type", string(props.OsType))
+
+		if props.Count != nil {
+			d.Set("node_count", int(*props.Count))
+		}
+
+		enableAutoScaling := false
+		if props.EnableAutoScaling != nil {
+			enableAutoScaling = *props.EnableAutoScaling
+		}
+		d.Set("enable_auto_scaling", enableAutoScaling)
+
+		minCount := 0
+		if props.MinCount != nil {
+			minCount = int(*props.MinCount)
+		}
+		d.Set("min_count", minCount)
+
+		maxCount := 0
+		if props.MaxCount != nil {
+			maxCount = int(*props.MaxCount)
+		}
+		d.Set("max_count", maxCount)
+
+		if props.OsDiskSizeGB != nil {
+			d.Set("os_disk_size_gb", int(*props.OsDiskSizeGB))
+		}
+
+		if props.MaxPods != nil {
+			d.Set("max_pods", int(*props.MaxPods))
+		}
+
+		d.Set("orchestrator_version", props.OrchestratorVersion)
+		d.Set("vnet_subnet_id", props.VnetSubnetID)
+
+		if err := d.Set("availability_zones", utils.FlattenStringSlice(props.AvailabilityZones)); err != nil {
+			return fmt.Errorf("Error setting `availability_zones`: %+v", err)
+		}
+
+		if err := d.Set("node_taints", utils.FlattenStringSlice(props.NodeTaints)); err != nil {
+			return fmt.Errorf("Error setting `node_taints`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceArmKubernetesClusterNodePoolDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).containers.AgentPoolsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	clusterName := id.Path["managedClusters"]
+	name := id.Path["agentPools"]
+
+	azureRMLockByName(clusterName, kubernetesClusterResourceName)
+	defer azureRMUnlockByName(clusterName, kubernetesClusterResourceName)
+
+	future, err := client.Delete(ctx, resourceGroup, clusterName, name)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for deletion of Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+// validateKubernetesClusterNodePoolVersion ensures the `orchestrator_version` is available, doesn't exceed the version
+// of the Control Plane and (when upgrading) doesn't skip a minor version
+func validateKubernetesClusterNodePoolVersion(d *schema.ResourceData, meta interface{}, cluster containerservice.ManagedCluster) error {
+	ctx := meta.(*ArmClient).StopContext
+
+	oldVersion, newVersion := d.GetChange("orchestrator_version")
+	currentVersion := oldVersion.(string)
+	targetVersion := newVersion.(string)
+	if targetVersion == "" {
+		return nil
+	}
+
+	if cluster.Location != nil {
+		if err := validateKubernetesVersionIsAvailable(ctx, meta, azure.NormalizeLocation(*cluster.Location), targetVersion); err != nil {
+			return fmt.Errorf("Error validating `orchestrator_version`: %+v", err)
+		}
+	}
+
+	if props := cluster.ManagedClusterProperties; props != nil && props.KubernetesVersion != nil {
+		if err := validateKubernetesNodePoolVersion(*props.KubernetesVersion, targetVersion); err != nil {
+			return fmt.Errorf("Error validating `orchestrator_version`: %+v", err)
+		}
+	}
+
+	if !d.IsNewResource() && currentVersion != "" {
+		if err := validateKubernetesVersionUpgrade(currentVersion, targetVersion); err != nil {
+			return fmt.Errorf("Error validating `orchestrator_version`: %+v", err)
+		}
+	}
+
+	return nil
+}
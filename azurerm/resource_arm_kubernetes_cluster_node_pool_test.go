@@ -0,0 +1,352 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+)
+
+func TestAccAzureRMKubernetesClusterNodePool_basic(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster_node_pool.test"
+	ri := tf.AccRandTimeInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	config := testAccAzureRMKubernetesClusterNodePool_basic(ri, clientId, clientSecret, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterNodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "node_count", "1"),
+					resource.TestCheckResourceAttr(resourceName, "os_type", "Linux"),
+					resource.TestCheckResourceAttrSet(resourceName, "max_pods"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesClusterNodePool_requiresImport(t *testing.T) {
+	if !requireResourcesToBeImported {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azurerm_kubernetes_cluster_node_pool.test"
+	ri := tf.AccRandTimeInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterNodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesClusterNodePool_basic(ri, clientId, clientSecret, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccAzureRMKubernetesClusterNodePool_requiresImport(ri, clientId, clientSecret, location),
+				ExpectError: testRequiresImportError("azurerm_kubernetes_cluster_node_pool"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesClusterNodePool_manualScale(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster_node_pool.test"
+	ri := tf.AccRandTimeInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterNodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesClusterNodePool_manualScale(ri, clientId, clientSecret, location, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "node_count", "1"),
+				),
+			},
+			{
+				Config: testAccAzureRMKubernetesClusterNodePool_manualScale(ri, clientId, clientSecret, location, 3),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "node_count", "3"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesClusterNodePool_autoScale(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster_node_pool.test"
+	ri := tf.AccRandTimeInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	config := testAccAzureRMKubernetesClusterNodePool_autoScale(ri, clientId, clientSecret, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterNodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "enable_auto_scaling", "true"),
+					resource.TestCheckResourceAttr(resourceName, "min_count", "1"),
+					resource.TestCheckResourceAttr(resourceName, "max_count", "3"),
+					resource.TestCheckResourceAttr(resourceName, "availability_zones.#", "2"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"node_count"},
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesClusterNodePool_upgrade(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster_node_pool.test"
+	ri := tf.AccRandTimeInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterNodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesClusterNodePool_orchestratorVersion(ri, clientId, clientSecret, location, "1.13.10"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "orchestrator_version", "1.13.10"),
+				),
+			},
+			{
+				Config: testAccAzureRMKubernetesClusterNodePool_orchestratorVersion(ri, clientId, clientSecret, location, "1.14.6"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "orchestrator_version", "1.14.6"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesClusterNodePool_nodeTaints(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster_node_pool.test"
+	ri := tf.AccRandTimeInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	config := testAccAzureRMKubernetesClusterNodePool_nodeTaints(ri, clientId, clientSecret, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterNodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "node_taints.0", "key=value:NoSchedule"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckAzureRMKubernetesClusterNodePoolExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// Ensure we have enough information in state to look up in API
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		clusterId, err := parseAzureResourceID(rs.Primary.Attributes["kubernetes_cluster_id"])
+		if err != nil {
+			return err
+		}
+		resourceGroup := clusterId.ResourceGroup
+		clusterName := clusterId.Path["managedClusters"]
+
+		client := testAccProvider.Meta().(*ArmClient).containers.AgentPoolsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		pool, err := client.Get(ctx, resourceGroup, clusterName, name)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on agentPoolsClient: %+v", err)
+		}
+
+		if pool.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Bad: Node Pool %q (Kubernetes Cluster %q / Resource Group: %q) does not exist", name, clusterName, resourceGroup)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMKubernetesClusterNodePoolDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).containers.AgentPoolsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_kubernetes_cluster_node_pool" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		clusterId, err := parseAzureResourceID(rs.Primary.Attributes["kubernetes_cluster_id"])
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Get(ctx, clusterId.ResourceGroup, clusterId.Path["managedClusters"], name)
+		if err != nil {
+			return nil
+		}
+
+		if resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("Node Pool still exists:\n%#v", resp)
+		}
+	}
+
+	return nil
+}
+
+func testAccAzureRMKubernetesClusterNodePool_basic(rInt int, clientId string, clientSecret string, location string) string {
+	template := testAccAzureRMKubernetesCluster_virtualMachineScaleSets(rInt, clientId, clientSecret, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_node_pool" "test" {
+  name                  = "internal"
+  kubernetes_cluster_id = "${azurerm_kubernetes_cluster.test.id}"
+  vm_size               = "Standard_DS2_v2"
+  node_count            = 1
+}
+`, template)
+}
+
+func testAccAzureRMKubernetesClusterNodePool_requiresImport(rInt int, clientId string, clientSecret string, location string) string {
+	template := testAccAzureRMKubernetesClusterNodePool_basic(rInt, clientId, clientSecret, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_node_pool" "import" {
+  name                  = "${azurerm_kubernetes_cluster_node_pool.test.name}"
+  kubernetes_cluster_id = "${azurerm_kubernetes_cluster_node_pool.test.kubernetes_cluster_id}"
+  vm_size               = "${azurerm_kubernetes_cluster_node_pool.test.vm_size}"
+  node_count            = "${azurerm_kubernetes_cluster_node_pool.test.node_count}"
+}
+`, template)
+}
+
+func testAccAzureRMKubernetesClusterNodePool_manualScale(rInt int, clientId string, clientSecret string, location string, nodeCount int) string {
+	template := testAccAzureRMKubernetesCluster_virtualMachineScaleSets(rInt, clientId, clientSecret, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_node_pool" "test" {
+  name                  = "internal"
+  kubernetes_cluster_id = "${azurerm_kubernetes_cluster.test.id}"
+  vm_size               = "Standard_DS2_v2"
+  node_count            = %d
+}
+`, template, nodeCount)
+}
+
+func testAccAzureRMKubernetesClusterNodePool_autoScale(rInt int, clientId string, clientSecret string, location string) string {
+	template := testAccAzureRMKubernetesCluster_virtualMachineScaleSets(rInt, clientId, clientSecret, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_node_pool" "test" {
+  name                  = "internal"
+  kubernetes_cluster_id = "${azurerm_kubernetes_cluster.test.id}"
+  vm_size               = "Standard_DS2_v2"
+  enable_auto_scaling   = true
+  min_count             = 1
+  max_count             = 3
+  availability_zones    = ["1", "2"]
+}
+`, template)
+}
+
+func testAccAzureRMKubernetesClusterNodePool_nodeTaints(rInt int, clientId string, clientSecret string, location string) string {
+	template := testAccAzureRMKubernetesCluster_virtualMachineScaleSets(rInt, clientId, clientSecret, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_node_pool" "test" {
+  name                  = "internal"
+  kubernetes_cluster_id = "${azurerm_kubernetes_cluster.test.id}"
+  vm_size               = "Standard_DS2_v2"
+  node_count            = 1
+  node_taints           = ["key=value:NoSchedule"]
+}
+`, template)
+}
+
+func testAccAzureRMKubernetesClusterNodePool_orchestratorVersion(rInt int, clientId string, clientSecret string, location string, orchestratorVersion string) string {
+	template := testAccAzureRMKubernetesCluster_upgradeNodePools(rInt, location, clientId, clientSecret, "1.14.6", "1.14.6")
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_node_pool" "test" {
+  name                  = "internal"
+  kubernetes_cluster_id = "${azurerm_kubernetes_cluster.test.id}"
+  vm_size               = "Standard_DS2_v2"
+  node_count            = 1
+  orchestrator_version  = "%s"
+}
+`, template, orchestratorVersion)
+}
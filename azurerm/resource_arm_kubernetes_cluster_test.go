@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -81,6 +82,38 @@ func TestAccAzureRMKubernetesCluster_requiresImport(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMKubernetesCluster_managedIdentity(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster.test"
+	ri := tf.AccRandTimeInt()
+	config := testAccAzureRMKubernetesCluster_managedIdentity(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "identity.0.type", "SystemAssigned"),
+					resource.TestCheckResourceAttrSet(resourceName, "identity.0.principal_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "identity.0.tenant_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "kubelet_identity.0.client_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "kubelet_identity.0.object_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "kubelet_identity.0.user_assigned_identity_id"),
+					resource.TestCheckResourceAttr(resourceName, "service_principal.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccAzureRMKubernetesCluster_roleBasedAccessControl(t *testing.T) {
 	resourceName := "azurerm_kubernetes_cluster.test"
 	ri := tf.AccRandTimeInt()
@@ -139,6 +172,10 @@ func TestAccAzureRMKubernetesCluster_roleBasedAccessControlAAD(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "role_based_access_control.0.azure_active_directory.0.tenant_id"),
 					resource.TestCheckResourceAttr(resourceName, "kube_admin_config.#", "1"),
 					resource.TestCheckResourceAttrSet(resourceName, "kube_admin_config_raw"),
+					resource.TestCheckResourceAttr(resourceName, "kube_config_exec.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "kube_config_exec.0.command", "kubelogin"),
+					resource.TestCheckResourceAttrSet(resourceName, "kube_config_exec.0.host"),
+					resource.TestCheckResourceAttrSet(resourceName, "kube_config_exec_raw"),
 				),
 			},
 			{
@@ -259,6 +296,105 @@ func TestAccAzureRMKubernetesCluster_upgradeConfig(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMKubernetesCluster_upgradeControlPlaneThenNodePools(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesCluster_upgradeNodePools(ri, location, clientId, clientSecret, "1.13.10", "1.13.10"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "kubernetes_version", "1.13.10"),
+					resource.TestCheckResourceAttr(resourceName, "agent_pool_profile.0.orchestrator_version", "1.13.10"),
+				),
+			},
+			{
+				Config: testAccAzureRMKubernetesCluster_upgradeNodePools(ri, location, clientId, clientSecret, "1.14.6", "1.13.10"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "kubernetes_version", "1.14.6"),
+					resource.TestCheckResourceAttr(resourceName, "agent_pool_profile.0.orchestrator_version", "1.13.10"),
+				),
+			},
+			{
+				Config: testAccAzureRMKubernetesCluster_upgradeNodePools(ri, location, clientId, clientSecret, "1.14.6", "1.14.6"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "kubernetes_version", "1.14.6"),
+					resource.TestCheckResourceAttr(resourceName, "agent_pool_profile.0.orchestrator_version", "1.14.6"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesCluster_upgradeNodePoolsFollowingKubernetesVersion(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesCluster_upgradeNodePoolsFollowingKubernetesVersion(ri, location, clientId, clientSecret, "1.13.10"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					testCheckAzureRMKubernetesClusterNodePoolVersion(resourceName, "default", "1.13.10"),
+					resource.TestCheckResourceAttr(resourceName, "kubernetes_version", "1.13.10"),
+					resource.TestCheckResourceAttr(resourceName, "agent_pool_profile.0.orchestrator_version", ""),
+				),
+			},
+			{
+				Config: testAccAzureRMKubernetesCluster_upgradeNodePoolsFollowingKubernetesVersion(ri, location, clientId, clientSecret, "1.14.6"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					testCheckAzureRMKubernetesClusterNodePoolVersion(resourceName, "default", "1.14.6"),
+					resource.TestCheckResourceAttr(resourceName, "kubernetes_version", "1.14.6"),
+					resource.TestCheckResourceAttr(resourceName, "agent_pool_profile.0.orchestrator_version", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesCluster_upgradeSkippingMinorVersion(t *testing.T) {
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesCluster_upgradeNodePools(ri, location, clientId, clientSecret, "1.13.10", "1.13.10"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists("azurerm_kubernetes_cluster.test"),
+				),
+			},
+			{
+				Config:      testAccAzureRMKubernetesCluster_upgradeNodePools(ri, location, clientId, clientSecret, "1.15.3", "1.13.10"),
+				ExpectError: regexp.MustCompile("must be upgraded one minor version at a time"),
+			},
+		},
+	})
+}
+
 func TestAccAzureRMKubernetesCluster_internalNetwork(t *testing.T) {
 	resourceName := "azurerm_kubernetes_cluster.test"
 	ri := tf.AccRandTimeInt()
@@ -770,6 +906,36 @@ func testCheckAzureRMKubernetesClusterExists(resourceName string) resource.TestC
 	}
 }
 
+func testCheckAzureRMKubernetesClusterNodePoolVersion(resourceName, poolName, version string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).containers.AgentPoolsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		pool, err := client.Get(ctx, resourceGroup, name, poolName)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on agentPoolsClient: %+v", err)
+		}
+
+		if pool.ManagedClusterAgentPoolProfileProperties == nil || pool.ManagedClusterAgentPoolProfileProperties.OrchestratorVersion == nil {
+			return fmt.Errorf("Bad: Node Pool %q (Managed Kubernetes Cluster %q / Resource Group %q) has no Orchestrator Version", poolName, name, resourceGroup)
+		}
+
+		if actual := *pool.ManagedClusterAgentPoolProfileProperties.OrchestratorVersion; actual != version {
+			return fmt.Errorf("Bad: expected Node Pool %q (Managed Kubernetes Cluster %q / Resource Group %q) to be running %q but got %q", poolName, name, resourceGroup, version, actual)
+		}
+
+		return nil
+	}
+}
+
 func testCheckAzureRMKubernetesClusterDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*ArmClient).containers.KubernetesClustersClient
 
@@ -823,6 +989,33 @@ resource "azurerm_kubernetes_cluster" "test" {
 `, rInt, location, rInt, rInt, clientId, clientSecret)
 }
 
+func testAccAzureRMKubernetesCluster_managedIdentity(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  dns_prefix          = "acctestaks%d"
+
+  agent_pool_profile {
+    name    = "default"
+    type    = "VirtualMachineScaleSets"
+    count   = "1"
+    vm_size = "Standard_DS2_v2"
+  }
+
+  identity {
+    type = "SystemAssigned"
+  }
+}
+`, rInt, location, rInt, rInt)
+}
+
 func testAccAzureRMKubernetesCluster_requiresImport(rInt int, clientId, clientSecret, location string) string {
 	template := testAccAzureRMKubernetesCluster_basic(rInt, clientId, clientSecret, location)
 	return fmt.Sprintf(`
@@ -1274,6 +1467,65 @@ resource "azurerm_kubernetes_cluster" "test" {
 `, rInt, location, rInt, rInt, version, rInt, clientId, clientSecret)
 }
 
+func testAccAzureRMKubernetesCluster_upgradeNodePools(rInt int, location, clientId, clientSecret, kubernetesVersion, orchestratorVersion string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  dns_prefix          = "acctestaks%d"
+  kubernetes_version  = "%s"
+
+  agent_pool_profile {
+    name                 = "default"
+    type                 = "VirtualMachineScaleSets"
+    count                = "1"
+    vm_size              = "Standard_DS2_v2"
+    orchestrator_version = "%s"
+  }
+
+  service_principal {
+    client_id     = "%s"
+    client_secret = "%s"
+  }
+}
+`, rInt, location, rInt, rInt, kubernetesVersion, orchestratorVersion, clientId, clientSecret)
+}
+
+func testAccAzureRMKubernetesCluster_upgradeNodePoolsFollowingKubernetesVersion(rInt int, location, clientId, clientSecret, kubernetesVersion string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  dns_prefix          = "acctestaks%d"
+  kubernetes_version  = "%s"
+
+  agent_pool_profile {
+    name    = "default"
+    type    = "VirtualMachineScaleSets"
+    count   = "1"
+    vm_size = "Standard_DS2_v2"
+  }
+
+  service_principal {
+    client_id     = "%s"
+    client_secret = "%s"
+  }
+}
+`, rInt, location, rInt, rInt, kubernetesVersion, clientId, clientSecret)
+}
+
 func testAccAzureRMKubernetesCluster_advancedNetworking(rInt int, clientId string, clientSecret string, location string, networkPlugin string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
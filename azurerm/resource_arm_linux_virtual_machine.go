@@ -0,0 +1,561 @@
+package azurerm
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmLinuxVirtualMachine() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmLinuxVirtualMachineCreate,
+		Read:   resourceArmLinuxVirtualMachineRead,
+		Update: resourceArmLinuxVirtualMachineUpdate,
+		Delete: resourceArmLinuxVirtualMachineDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"location": azure.SchemaLocation(),
+
+			"admin_username": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"network_interface_ids": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: azure.ValidateResourceID,
+				},
+			},
+
+			"os_disk": virtualMachineOSDiskSchema(),
+
+			"size": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"admin_password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"admin_ssh_key": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Set:      resourceArmLinuxVirtualMachineSSHKeyHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"public_key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"username": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+					},
+				},
+			},
+
+			"allow_extension_operations": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+
+			"availability_set_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+				// the Compute/VM API is broken and returns the Availability Set name in UPPERCASE :shrug:
+				DiffSuppressFunc: suppress.CaseDifference,
+				ConflictsWith:    []string{"zone"},
+			},
+
+			"boot_diagnostics": virtualMachineBootDiagnosticsSchema(),
+
+			"computer_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"custom_data": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				ValidateFunc: validate.Base64String(),
+			},
+
+			"disable_password_authentication": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+
+			"identity": virtualMachineIdentitySchema(),
+
+			"provision_vm_agent": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+
+			"source_image_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"source_image_reference": virtualMachineSourceImageReferenceSchema(),
+
+			"zone": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validate.NoEmptyStrings,
+				ConflictsWith: []string{"availability_set_id"},
+			},
+
+			"tags": tagsSchema(),
+
+			"private_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"virtual_machine_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmLinuxVirtualMachineCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	azureRMLockByName(name, virtualMachineResourceName)
+	defer azureRMUnlockByName(name, virtualMachineResourceName)
+
+	if requireResourcesToBeImported {
+		existing, err := client.Get(ctx, resourceGroup, name, "")
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for existing Linux Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_linux_virtual_machine", *existing.ID)
+		}
+	}
+
+	location := azure.NormalizeLocation(d.Get("location").(string))
+	adminUsername := d.Get("admin_username").(string)
+	disablePasswordAuthentication := d.Get("disable_password_authentication").(bool)
+	sshKeys := expandLinuxVirtualMachineSSHKeys(d.Get("admin_ssh_key").(*schema.Set).List())
+	tags := d.Get("tags").(map[string]interface{})
+
+	computerName := d.Get("computer_name").(string)
+	if computerName == "" {
+		computerName = name
+	}
+
+	identity, err := expandVirtualMachineIdentity(d.Get("identity").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("Error expanding `identity`: %+v", err)
+	}
+
+	sourceImageReference, err := expandVirtualMachineSourceImageReference(d)
+	if err != nil {
+		return err
+	}
+
+	networkInterfaceIds := expandVirtualMachineNetworkInterfaceIDs(d.Get("network_interface_ids").([]interface{}))
+
+	params := compute.VirtualMachine{
+		Name:     utils.String(name),
+		Location: utils.String(location),
+		Identity: identity,
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			HardwareProfile: &compute.HardwareProfile{
+				VMSize: compute.VirtualMachineSizeTypes(d.Get("size").(string)),
+			},
+			OsProfile: &compute.OSProfile{
+				AdminUsername:            utils.String(adminUsername),
+				ComputerName:             utils.String(computerName),
+				AllowExtensionOperations: utils.Bool(d.Get("allow_extension_operations").(bool)),
+				LinuxConfiguration: &compute.LinuxConfiguration{
+					DisablePasswordAuthentication: utils.Bool(disablePasswordAuthentication),
+					ProvisionVMAgent:              utils.Bool(d.Get("provision_vm_agent").(bool)),
+					SSH: &compute.SSHConfiguration{
+						PublicKeys: &sshKeys,
+					},
+				},
+			},
+			NetworkProfile: &compute.NetworkProfile{
+				NetworkInterfaces: &networkInterfaceIds,
+			},
+			StorageProfile: &compute.StorageProfile{
+				ImageReference: sourceImageReference,
+				OsDisk:         expandVirtualMachineOSDisk(d.Get("os_disk").([]interface{}), compute.Linux),
+			},
+			DiagnosticsProfile: expandVirtualMachineBootDiagnostics(d.Get("boot_diagnostics").([]interface{})),
+		},
+		Tags: expandTags(tags),
+	}
+
+	if adminPassword := d.Get("admin_password").(string); adminPassword != "" {
+		params.OsProfile.AdminPassword = utils.String(adminPassword)
+	}
+
+	if disablePasswordAuthentication {
+		if len(sshKeys) == 0 {
+			return fmt.Errorf("At least one `admin_ssh_key` must be specified when `disable_password_authentication` is set to `true`")
+		}
+	} else if params.OsProfile.AdminPassword == nil {
+		return fmt.Errorf("An `admin_password` must be specified if `disable_password_authentication` is set to `false`")
+	}
+
+	if v, ok := d.GetOk("availability_set_id"); ok {
+		params.AvailabilitySet = &compute.SubResource{
+			ID: utils.String(v.(string)),
+		}
+	}
+
+	if v, ok := d.GetOk("custom_data"); ok {
+		params.OsProfile.CustomData = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("zone"); ok {
+		params.Zones = &[]string{
+			v.(string),
+		}
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, name, params)
+	if err != nil {
+		return fmt.Errorf("Error creating Linux Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for creation of Linux Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, name, "")
+	if err != nil {
+		return fmt.Errorf("Error retrieving Linux Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("Error retrieving Linux Virtual Machine %q (Resource Group %q): `id` was nil", name, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+	return resourceArmLinuxVirtualMachineRead(d, meta)
+}
+
+func resourceArmLinuxVirtualMachineRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmClient
+	disksClient := meta.(*ArmClient).diskClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["virtualMachines"]
+
+	resp, err := client.Get(ctx, resourceGroup, name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Linux Virtual Machine %q was not found in Resource Group %q - removing from state!", name, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Linux Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.Set("name", name)
+	d.Set("resource_group_name", resourceGroup)
+	if location := resp.Location; location != nil {
+		d.Set("location", azure.NormalizeLocation(*location))
+	}
+
+	zone := ""
+	if resp.Zones != nil && len(*resp.Zones) > 0 {
+		zone = (*resp.Zones)[0]
+	}
+	d.Set("zone", zone)
+
+	if err := d.Set("identity", flattenVirtualMachineIdentity(resp.Identity)); err != nil {
+		return fmt.Errorf("Error setting `identity`: %+v", err)
+	}
+
+	props := resp.VirtualMachineProperties
+	if props == nil {
+		return fmt.Errorf("Error retrieving Linux Virtual Machine %q (Resource Group %q): `properties` was nil", name, resourceGroup)
+	}
+
+	availabilitySetId := ""
+	if props.AvailabilitySet != nil && props.AvailabilitySet.ID != nil {
+		availabilitySetId = *props.AvailabilitySet.ID
+	}
+	d.Set("availability_set_id", availabilitySetId)
+
+	if err := d.Set("boot_diagnostics", flattenVirtualMachineBootDiagnostics(props.DiagnosticsProfile)); err != nil {
+		return fmt.Errorf("Error setting `boot_diagnostics`: %+v", err)
+	}
+
+	if profile := props.HardwareProfile; profile != nil {
+		d.Set("size", string(profile.VMSize))
+	}
+
+	if profile := props.NetworkProfile; profile != nil {
+		if err := d.Set("network_interface_ids", flattenVirtualMachineNetworkInterfaceIDs(profile.NetworkInterfaces)); err != nil {
+			return fmt.Errorf("Error setting `network_interface_ids`: %+v", err)
+		}
+	}
+
+	if profile := props.OsProfile; profile != nil {
+		d.Set("admin_username", profile.AdminUsername)
+		d.Set("allow_extension_operations", profile.AllowExtensionOperations)
+		d.Set("computer_name", profile.ComputerName)
+
+		if config := profile.LinuxConfiguration; config != nil {
+			d.Set("disable_password_authentication", config.DisablePasswordAuthentication)
+			d.Set("provision_vm_agent", config.ProvisionVMAgent)
+
+			flattenedSSHKeys, err := flattenLinuxVirtualMachineSSHKeys(config.SSH)
+			if err != nil {
+				return fmt.Errorf("Error flattening `admin_ssh_key`: %+v", err)
+			}
+			if err := d.Set("admin_ssh_key", schema.NewSet(resourceArmLinuxVirtualMachineSSHKeyHash, *flattenedSSHKeys)); err != nil {
+				return fmt.Errorf("Error setting `admin_ssh_key`: %+v", err)
+			}
+		}
+	}
+
+	if profile := props.StorageProfile; profile != nil {
+		flattenedOSDisk, err := flattenVirtualMachineOSDisk(ctx, &disksClient, profile.OsDisk)
+		if err != nil {
+			return fmt.Errorf("Error flattening `os_disk`: %+v", err)
+		}
+		if err := d.Set("os_disk", flattenedOSDisk); err != nil {
+			return fmt.Errorf("Error setting `os_disk`: %+v", err)
+		}
+
+		sourceImageId := ""
+		if profile.ImageReference != nil && profile.ImageReference.ID != nil {
+			sourceImageId = *profile.ImageReference.ID
+		}
+		d.Set("source_image_id", sourceImageId)
+
+		if err := d.Set("source_image_reference", flattenVirtualMachineSourceImageReference(profile.ImageReference)); err != nil {
+			return fmt.Errorf("Error setting `source_image_reference`: %+v", err)
+		}
+	}
+
+	d.Set("virtual_machine_id", props.VMID)
+
+	privateIPAddress := ""
+	if props.NetworkProfile != nil {
+		ipAddress, err := determineVirtualMachineIPAddress(ctx, meta, props)
+		if err != nil {
+			log.Printf("[DEBUG] Unable to determine the IP Address for Linux Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+		privateIPAddress = ipAddress
+	}
+	d.Set("private_ip_address", privateIPAddress)
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmLinuxVirtualMachineUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["virtualMachines"]
+
+	azureRMLockByName(name, virtualMachineResourceName)
+	defer azureRMUnlockByName(name, virtualMachineResourceName)
+
+	update := compute.VirtualMachineUpdate{
+		VirtualMachineProperties: &compute.VirtualMachineProperties{},
+	}
+
+	if d.HasChange("boot_diagnostics") {
+		update.VirtualMachineProperties.DiagnosticsProfile = expandVirtualMachineBootDiagnostics(d.Get("boot_diagnostics").([]interface{}))
+	}
+
+	if d.HasChange("identity") {
+		identity, err := expandVirtualMachineIdentity(d.Get("identity").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("Error expanding `identity`: %+v", err)
+		}
+		update.Identity = identity
+	}
+
+	if d.HasChange("network_interface_ids") {
+		networkInterfaceIds := expandVirtualMachineNetworkInterfaceIDs(d.Get("network_interface_ids").([]interface{}))
+		update.VirtualMachineProperties.NetworkProfile = &compute.NetworkProfile{
+			NetworkInterfaces: &networkInterfaceIds,
+		}
+	}
+
+	if d.HasChange("os_disk") {
+		update.VirtualMachineProperties.StorageProfile = &compute.StorageProfile{
+			OsDisk: expandVirtualMachineOSDisk(d.Get("os_disk").([]interface{}), compute.Linux),
+		}
+	}
+
+	if d.HasChange("size") {
+		update.VirtualMachineProperties.HardwareProfile = &compute.HardwareProfile{
+			VMSize: compute.VirtualMachineSizeTypes(d.Get("size").(string)),
+		}
+	}
+
+	if d.HasChange("tags") {
+		tags := d.Get("tags").(map[string]interface{})
+		update.Tags = expandTags(tags)
+	}
+
+	// changing the NIC's or resizing the VM/OS Disk requires the VM to be deallocated
+	shouldDeallocate := virtualMachineShouldBeDeallocated(d) || d.HasChange("network_interface_ids")
+	if err := updateVirtualMachine(ctx, &client, resourceGroup, name, update, shouldDeallocate); err != nil {
+		return fmt.Errorf("Error updating Linux Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	return resourceArmLinuxVirtualMachineRead(d, meta)
+}
+
+func resourceArmLinuxVirtualMachineDelete(d *schema.ResourceData, meta interface{}) error {
+	return deleteVirtualMachine(d, meta)
+}
+
+func expandLinuxVirtualMachineSSHKeys(input []interface{}) []compute.SSHPublicKey {
+	output := make([]compute.SSHPublicKey, 0)
+
+	for _, v := range input {
+		raw := v.(map[string]interface{})
+
+		username := raw["username"].(string)
+		output = append(output, compute.SSHPublicKey{
+			KeyData: utils.String(raw["public_key"].(string)),
+			Path:    utils.String(formatLinuxVirtualMachineSSHKeyPath(username)),
+		})
+	}
+
+	return output
+}
+
+func flattenLinuxVirtualMachineSSHKeys(input *compute.SSHConfiguration) (*[]interface{}, error) {
+	output := make([]interface{}, 0)
+	if input == nil || input.PublicKeys == nil {
+		return &output, nil
+	}
+
+	for _, v := range *input.PublicKeys {
+		if v.KeyData == nil || v.Path == nil {
+			continue
+		}
+
+		username, err := parseLinuxVirtualMachineUsernameFromSSHKeyPath(*v.Path)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing username from %q: %+v", *v.Path, err)
+		}
+
+		output = append(output, map[string]interface{}{
+			"public_key": *v.KeyData,
+			"username":   *username,
+		})
+	}
+
+	return &output, nil
+}
+
+func formatLinuxVirtualMachineSSHKeyPath(username string) string {
+	return fmt.Sprintf("/home/%s/.ssh/authorized_keys", username)
+}
+
+func parseLinuxVirtualMachineUsernameFromSSHKeyPath(input string) (*string, error) {
+	// the path is in the format `/home/{username}/.ssh/authorized_keys`
+	segments := strings.Split(strings.TrimPrefix(input, "/"), "/")
+	if len(segments) != 4 || segments[0] != "home" || segments[1] == "" || segments[2] != ".ssh" || segments[3] != "authorized_keys" {
+		return nil, fmt.Errorf("Expected a path in the format `/home/{username}/.ssh/authorized_keys` but got %q", input)
+	}
+
+	return &segments[1], nil
+}
+
+func resourceArmLinuxVirtualMachineSSHKeyHash(v interface{}) int {
+	var buf bytes.Buffer
+
+	if m, ok := v.(map[string]interface{}); ok {
+		buf.WriteString(fmt.Sprintf("%s-", m["public_key"].(string)))
+		buf.WriteString(fmt.Sprintf("%s", m["username"].(string)))
+	}
+
+	return hashcode.String(buf.String())
+}
@@ -23,7 +23,7 @@ func resourceArmManagedDisk() *schema.Resource {
 		Delete: resourceArmManagedDiskDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceArmManagedDiskImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -99,6 +99,26 @@ func resourceArmManagedDisk() *schema.Resource {
 				ValidateFunc: validateDiskSizeGB,
 			},
 
+			"disk_iops_read_write": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"disk_mbps_read_write": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"deallocate_virtual_machine_on_resize": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"encryption_settings": encryptionSettingsSchema(),
 
 			"tags": tagsSchema(),
@@ -173,6 +193,20 @@ func resourceArmManagedDiskCreateUpdate(d *schema.ResourceData, meta interface{}
 		createDisk.DiskProperties.DiskSizeGB = &diskSize
 	}
 
+	if v, ok := d.GetOk("disk_iops_read_write"); ok {
+		if skuName != compute.UltraSSDLRS {
+			return fmt.Errorf("[ERROR] disk_iops_read_write is only available for UltraSSD disks")
+		}
+		createDisk.DiskProperties.DiskIOPSReadWrite = utils.Int64(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("disk_mbps_read_write"); ok {
+		if skuName != compute.UltraSSDLRS {
+			return fmt.Errorf("[ERROR] disk_mbps_read_write is only available for UltraSSD disks")
+		}
+		createDisk.DiskProperties.DiskMBpsReadWrite = utils.Int32(int32(v.(int)))
+	}
+
 	createOption := d.Get("create_option").(string)
 	createDisk.CreationData = &compute.CreationData{
 		CreateOption: compute.DiskCreateOption(createOption),
@@ -206,13 +240,28 @@ func resourceArmManagedDiskCreateUpdate(d *schema.ResourceData, meta interface{}
 		createDisk.EncryptionSettings = expandManagedDiskEncryptionSettings(settings)
 	}
 
+	// an attached disk can only be grown whilst the Virtual Machine using it is deallocated
+	var virtualMachineToStart *ResourceID
+	if !d.IsNewResource() && d.HasChange("disk_size_gb") {
+		virtualMachineId, err := resourceArmManagedDiskDeallocateVirtualMachineForResize(d, meta, resGroup, name)
+		virtualMachineToStart = virtualMachineId
+		if err != nil {
+			return resourceArmManagedDiskStartVirtualMachineAfterError(meta, virtualMachineToStart, err)
+		}
+	}
+
 	future, err := client.CreateOrUpdate(ctx, resGroup, name, createDisk)
+	if err == nil {
+		err = future.WaitForCompletionRef(ctx, client.Client)
+	}
 	if err != nil {
-		return err
+		return resourceArmManagedDiskStartVirtualMachineAfterError(meta, virtualMachineToStart, err)
 	}
 
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
-		return err
+	if virtualMachineToStart != nil {
+		if err := resourceArmManagedDiskStartVirtualMachine(meta, virtualMachineToStart); err != nil {
+			return err
+		}
 	}
 
 	read, err := client.Get(ctx, resGroup, name)
@@ -228,6 +277,13 @@ func resourceArmManagedDiskCreateUpdate(d *schema.ResourceData, meta interface{}
 	return resourceArmManagedDiskRead(d, meta)
 }
 
+func resourceArmManagedDiskImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// this is a behavioural flag rather than a property of the disk, so it's set to the default when importing
+	d.Set("deallocate_virtual_machine_on_resize", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceArmManagedDiskRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).diskClient
 	ctx := meta.(*ArmClient).StopContext
@@ -267,6 +323,16 @@ func resourceArmManagedDiskRead(d *schema.ResourceData, meta interface{}) error
 		if osType := props.OsType; osType != "" {
 			d.Set("os_type", string(osType))
 		}
+
+		// the API returns the performance figures for all disks, however they can only be configured for UltraSSD disks
+		if resp.Sku != nil && resp.Sku.Name == compute.UltraSSDLRS {
+			if iops := props.DiskIOPSReadWrite; iops != nil {
+				d.Set("disk_iops_read_write", *iops)
+			}
+			if mbps := props.DiskMBpsReadWrite; mbps != nil {
+				d.Set("disk_mbps_read_write", *mbps)
+			}
+		}
 	}
 
 	if resp.CreationData != nil {
@@ -312,6 +378,109 @@ func resourceArmManagedDiskDelete(d *schema.ResourceData, meta interface{}) erro
 	return nil
 }
 
+// resourceArmManagedDiskDeallocateVirtualMachineForResize deallocates the running Virtual Machine this
+// Managed Disk is attached to (if any) so that it can be resized, returning the ID of the Virtual Machine
+// which needs to be started again once the resize has completed
+func resourceArmManagedDiskDeallocateVirtualMachineForResize(d *schema.ResourceData, meta interface{}, resGroup string, name string) (*ResourceID, error) {
+	client := meta.(*ArmClient).diskClient
+	vmClient := meta.(*ArmClient).vmClient
+	ctx := meta.(*ArmClient).StopContext
+
+	disk, err := client.Get(ctx, resGroup, name)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving Managed Disk %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	if disk.ManagedBy == nil || *disk.ManagedBy == "" {
+		return nil, nil
+	}
+
+	virtualMachineId, err := parseAzureResourceID(*disk.ManagedBy)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing Virtual Machine ID %q: %+v", *disk.ManagedBy, err)
+	}
+	if _, ok := virtualMachineId.Path["virtualMachineScaleSets"]; ok {
+		return nil, fmt.Errorf("Error resizing Managed Disk %q (Resource Group %q): Managed Disks attached to Virtual Machine Scale Set instances cannot be resized", name, resGroup)
+	}
+	vmResourceGroup := virtualMachineId.ResourceGroup
+	vmName := virtualMachineId.Path["virtualMachines"]
+
+	powerState, err := getVirtualMachinePowerState(ctx, &vmClient, vmResourceGroup, vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	// a disk attached to a deallocated Virtual Machine can be resized as-is
+	if powerState == virtualMachinePowerStateDeallocated {
+		return nil, nil
+	}
+
+	if !d.Get("deallocate_virtual_machine_on_resize").(bool) {
+		return nil, fmt.Errorf("Error resizing Managed Disk %q (Resource Group %q): the disk is attached to Virtual Machine %q (Resource Group %q) which must be deallocated before the disk can be resized. Either deallocate the Virtual Machine or set `deallocate_virtual_machine_on_resize` to `true`", name, resGroup, vmName, vmResourceGroup)
+	}
+
+	azureRMLockByName(vmName, virtualMachineResourceName)
+	defer azureRMUnlockByName(vmName, virtualMachineResourceName)
+
+	log.Printf("[DEBUG] Deallocating Virtual Machine %q (Resource Group %q) to resize Managed Disk %q..", vmName, vmResourceGroup, name)
+	future, err := vmClient.Deallocate(ctx, vmResourceGroup, vmName)
+	if err != nil {
+		return nil, fmt.Errorf("Error deallocating Virtual Machine %q (Resource Group %q): %+v", vmName, vmResourceGroup, err)
+	}
+
+	// if the Virtual Machine was stopped (rather than running) we leave it deallocated
+	virtualMachineToStart := virtualMachineId
+	if powerState != virtualMachinePowerStateRunning {
+		virtualMachineToStart = nil
+	}
+
+	if err := future.WaitForCompletionRef(ctx, vmClient.Client); err != nil {
+		// the deallocation may have partially completed, so the caller still needs to start the Virtual Machine
+		return virtualMachineToStart, fmt.Errorf("Error waiting for deallocation of Virtual Machine %q (Resource Group %q): %+v", vmName, vmResourceGroup, err)
+	}
+	log.Printf("[DEBUG] Deallocated Virtual Machine %q (Resource Group %q).", vmName, vmResourceGroup)
+
+	return virtualMachineToStart, nil
+}
+
+// resourceArmManagedDiskStartVirtualMachineAfterError starts the Virtual Machine (if any) which was deallocated for the
+// resize when the resize fails, so that it's not left deallocated - returning both errors if it can't be started
+func resourceArmManagedDiskStartVirtualMachineAfterError(meta interface{}, virtualMachineId *ResourceID, err error) error {
+	if virtualMachineId == nil {
+		return err
+	}
+
+	if startErr := resourceArmManagedDiskStartVirtualMachine(meta, virtualMachineId); startErr != nil {
+		return fmt.Errorf("%+v\n\nAdditionally, the Virtual Machine couldn't be started again: %+v", err, startErr)
+	}
+
+	return err
+}
+
+func resourceArmManagedDiskStartVirtualMachine(meta interface{}, virtualMachineId *ResourceID) error {
+	vmClient := meta.(*ArmClient).vmClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resGroup := virtualMachineId.ResourceGroup
+	name := virtualMachineId.Path["virtualMachines"]
+
+	azureRMLockByName(name, virtualMachineResourceName)
+	defer azureRMUnlockByName(name, virtualMachineResourceName)
+
+	log.Printf("[DEBUG] Starting Virtual Machine %q (Resource Group %q)..", name, resGroup)
+	future, err := vmClient.Start(ctx, resGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error starting Virtual Machine %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, vmClient.Client); err != nil {
+		return fmt.Errorf("Error waiting for start of Virtual Machine %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+	log.Printf("[DEBUG] Started Virtual Machine %q (Resource Group %q).", name, resGroup)
+
+	return nil
+}
+
 func flattenAzureRmManagedDiskCreationData(d *schema.ResourceData, creationData *compute.CreationData) {
 	d.Set("create_option", string(creationData.CreateOption))
 	if ref := creationData.ImageReference; ref != nil {
@@ -3,6 +3,7 @@ package azurerm
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
@@ -275,6 +276,70 @@ func TestAccAzureRMManagedDisk_importEmpty_withZone(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMManagedDisk_ultraSSD(t *testing.T) {
+	resourceName := "azurerm_managed_disk.test"
+	ri := tf.AccRandTimeInt()
+	var d compute.Disk
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMManagedDiskDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMManagedDisk_ultraSSD(ri, testLocation(), 101, 10),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMManagedDiskExists(resourceName, &d, true),
+					resource.TestCheckResourceAttr(resourceName, "disk_iops_read_write", "101"),
+					resource.TestCheckResourceAttr(resourceName, "disk_mbps_read_write", "10"),
+				),
+			},
+			{
+				Config: testAccAzureRMManagedDisk_ultraSSD(ri, testLocation(), 102, 11),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMManagedDiskExists(resourceName, &d, true),
+					resource.TestCheckResourceAttr(resourceName, "disk_iops_read_write", "102"),
+					resource.TestCheckResourceAttr(resourceName, "disk_mbps_read_write", "11"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMManagedDisk_attachedDiskResize(t *testing.T) {
+	resourceName := "azurerm_managed_disk.test"
+	ri := tf.AccRandTimeInt()
+	var d compute.Disk
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMManagedDiskDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMManagedDisk_attached(ri, testLocation(), 10),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMManagedDiskExists(resourceName, &d, true),
+					resource.TestCheckResourceAttr(resourceName, "disk_size_gb", "10"),
+				),
+			},
+			{
+				Config: testAccAzureRMManagedDisk_attached(ri, testLocation(), 20),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMManagedDiskExists(resourceName, &d, true),
+					resource.TestCheckResourceAttr(resourceName, "disk_size_gb", "20"),
+					testCheckAzureRMManagedDiskAttachedVirtualMachineIsRunning(resourceName),
+				),
+			},
+		},
+	})
+}
+
 func testCheckAzureRMManagedDiskExists(resourceName string, d *compute.Disk, shouldExist bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -335,6 +400,51 @@ func testCheckAzureRMManagedDiskDestroy(s *terraform.State) error {
 	return nil
 }
 
+func testCheckAzureRMManagedDiskAttachedVirtualMachineIsRunning(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).diskClient
+		vmClient := testAccProvider.Meta().(*ArmClient).vmClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		disk, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on diskClient: %+v", err)
+		}
+		if disk.ManagedBy == nil {
+			return fmt.Errorf("Bad: ManagedDisk %q (resource group %q) is not attached to a Virtual Machine", name, resourceGroup)
+		}
+
+		id, err := parseAzureResourceID(*disk.ManagedBy)
+		if err != nil {
+			return err
+		}
+		vmName := id.Path["virtualMachines"]
+
+		view, err := vmClient.InstanceView(ctx, id.ResourceGroup, vmName)
+		if err != nil {
+			return fmt.Errorf("Bad: InstanceView on vmClient: %+v", err)
+		}
+
+		if view.Statuses != nil {
+			for _, status := range *view.Statuses {
+				if status.Code != nil && strings.EqualFold(*status.Code, "PowerState/running") {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("Bad: Virtual Machine %q (resource group %q) is not running", vmName, id.ResourceGroup)
+	}
+}
+
 func testDeleteAzureRMVirtualMachine(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -700,3 +810,108 @@ resource "azurerm_managed_disk" "test" {
 }
 `, rInt, location, rString, rString, rString, rInt)
 }
+
+func testAccAzureRMManagedDisk_ultraSSD(rInt int, location string, diskIOPS int, diskMBps int) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_managed_disk" "test" {
+  name                 = "acctestd-%d"
+  location             = "${azurerm_resource_group.test.location}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_type = "UltraSSD_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "4"
+  disk_iops_read_write = "%d"
+  disk_mbps_read_write = "%d"
+  zones                = ["1"]
+}
+`, rInt, location, rInt, diskIOPS, diskMBps)
+}
+
+func testAccAzureRMManagedDisk_attached(rInt int, location string, diskSize int) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctvn-%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "acctsub-%d"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  virtual_network_name = "${azurerm_virtual_network.test.name}"
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurerm_network_interface" "test" {
+  name                = "acctni-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  ip_configuration {
+    name                          = "testconfiguration1"
+    subnet_id                     = "${azurerm_subnet.test.id}"
+    private_ip_address_allocation = "Dynamic"
+  }
+}
+
+resource "azurerm_virtual_machine" "test" {
+  name                  = "acctvm-%d"
+  location              = "${azurerm_resource_group.test.location}"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  network_interface_ids = ["${azurerm_network_interface.test.id}"]
+  vm_size               = "Standard_F2"
+
+  storage_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  storage_os_disk {
+    name              = "myosdisk1"
+    caching           = "ReadWrite"
+    create_option     = "FromImage"
+    managed_disk_type = "Standard_LRS"
+  }
+
+  os_profile {
+    computer_name  = "hn%d"
+    admin_username = "testadmin"
+    admin_password = "Password1234!"
+  }
+
+  os_profile_linux_config {
+    disable_password_authentication = false
+  }
+}
+
+resource "azurerm_managed_disk" "test" {
+  name                                 = "acctestd-%d"
+  location                             = "${azurerm_resource_group.test.location}"
+  resource_group_name                  = "${azurerm_resource_group.test.name}"
+  storage_account_type                 = "Standard_LRS"
+  create_option                        = "Empty"
+  disk_size_gb                         = %d
+  deallocate_virtual_machine_on_resize = true
+}
+
+resource "azurerm_virtual_machine_data_disk_attachment" "test" {
+  managed_disk_id    = "${azurerm_managed_disk.test.id}"
+  virtual_machine_id = "${azurerm_virtual_machine.test.id}"
+  lun                = "0"
+  caching            = "None"
+}
+`, rInt, location, rInt, rInt, rInt, rInt, rInt, rInt, diskSize)
+}
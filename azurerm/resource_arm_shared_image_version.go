@@ -3,11 +3,14 @@ package azurerm
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
@@ -81,6 +84,13 @@ func resourceArmSharedImageVersion() *schema.Resource {
 				Default:  false,
 			},
 
+			"end_of_life_date": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validate.RFC3339Time,
+				DiffSuppressFunc: suppress.RFC3339Time,
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
@@ -130,13 +140,21 @@ func resourceArmSharedImageVersionCreateUpdate(d *schema.ResourceData, meta inte
 		Tags: expandTags(tags),
 	}
 
+	if v, ok := d.GetOk("end_of_life_date"); ok {
+		endOfLifeDate, _ := time.Parse(time.RFC3339, v.(string)) // validated by the schema
+		version.GalleryImageVersionProperties.PublishingProfile.EndOfLifeDate = &date.Time{
+			Time: endOfLifeDate,
+		}
+	}
+
+	// the Target Regions (and End of Life Date) can be updated in-place, which re-replicates the existing Image Version
 	future, err := client.CreateOrUpdate(ctx, resourceGroup, galleryName, imageName, imageVersion, version)
 	if err != nil {
-		return fmt.Errorf("Error creating Shared Image Version %q (Image %q / Gallery %q / Resource Group %q): %+v", imageVersion, imageName, galleryName, resourceGroup, err)
+		return fmt.Errorf("Error creating/updating Shared Image Version %q (Image %q / Gallery %q / Resource Group %q): %+v", imageVersion, imageName, galleryName, resourceGroup, err)
 	}
 
 	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
-		return fmt.Errorf("Error waiting for the creation of Shared Image Version %q (Image %q / Gallery %q / Resource Group %q): %+v", imageVersion, imageName, galleryName, resourceGroup, err)
+		return fmt.Errorf("Error waiting for the creation/update of Shared Image Version %q (Image %q / Gallery %q / Resource Group %q): %+v", imageVersion, imageName, galleryName, resourceGroup, err)
 	}
 
 	// TODO: poll?
@@ -188,6 +206,12 @@ func resourceArmSharedImageVersionRead(d *schema.ResourceData, meta interface{})
 		if profile := props.PublishingProfile; profile != nil {
 			d.Set("exclude_from_latest", profile.ExcludeFromLatest)
 
+			endOfLifeDate := ""
+			if profile.EndOfLifeDate != nil {
+				endOfLifeDate = profile.EndOfLifeDate.Format(time.RFC3339)
+			}
+			d.Set("end_of_life_date", endOfLifeDate)
+
 			flattenedRegions := flattenSharedImageVersionTargetRegions(profile.TargetRegions)
 			if err := d.Set("target_region", flattenedRegions); err != nil {
 				return fmt.Errorf("Error setting `target_region`: %+v", err)
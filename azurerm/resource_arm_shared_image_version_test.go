@@ -60,6 +60,56 @@ func TestAccAzureRMSharedImageVersion_basic(t *testing.T) {
 		},
 	})
 }
+func TestAccAzureRMSharedImageVersion_updateInPlace(t *testing.T) {
+	resourceName := "azurerm_shared_image_version.test"
+
+	ri := tf.AccRandTimeInt()
+	resourceGroup := fmt.Sprintf("acctestRG-%d", ri)
+	userName := "testadmin"
+	password := "Password1234!"
+	hostName := fmt.Sprintf("tftestcustomimagesrc%d", ri)
+	sshPort := "22"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSharedImageVersionDestroy,
+		Steps: []resource.TestStep{
+			{
+				// need to create a vm and then reference it in the image creation
+				Config:  testAccAzureRMSharedImageVersion_setup(ri, testLocation(), userName, password, hostName),
+				Destroy: false,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureVMExists("azurerm_virtual_machine.testsource", true),
+					testGeneralizeVMImage(resourceGroup, "testsource", userName, password, hostName, sshPort, testLocation()),
+				),
+			},
+			{
+				Config: testAccAzureRMSharedImageVersion_imageVersion(ri, testLocation(), userName, password, hostName),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSharedImageVersionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "target_region.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "end_of_life_date", ""),
+				),
+			},
+			{
+				Config: testAccAzureRMSharedImageVersion_imageVersionReplicated(ri, testLocation(), testAltLocation(), userName, password, hostName),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSharedImageVersionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "0.0.1"),
+					resource.TestCheckResourceAttr(resourceName, "target_region.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "end_of_life_date", "2099-01-01T00:00:00Z"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccAzureRMSharedImageVersion_requiresImport(t *testing.T) {
 	if !requireResourcesToBeImported {
 		t.Skip("Skipping since resources aren't required to be imported")
@@ -261,3 +311,30 @@ resource "azurerm_shared_image_version" "test" {
 }
 `, template, altLocation)
 }
+
+func testAccAzureRMSharedImageVersion_imageVersionReplicated(rInt int, location, altLocation, username, password, hostname string) string {
+	template := testAccAzureRMSharedImageVersion_provision(rInt, location, username, password, hostname)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_shared_image_version" "test" {
+  name                = "0.0.1"
+  gallery_name        = "${azurerm_shared_image_gallery.test.name}"
+  image_name          = "${azurerm_shared_image.test.name}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+  managed_image_id    = "${azurerm_image.test.id}"
+  end_of_life_date    = "2099-01-01T00:00:00Z"
+
+  target_region {
+    name                   = "${azurerm_resource_group.test.location}"
+    regional_replica_count = 1
+  }
+
+  target_region {
+    name                   = "%s"
+    regional_replica_count = 2
+  }
+}
+`, template, altLocation)
+}
@@ -302,6 +302,50 @@ func resourceArmSqlDatabase() *schema.Resource {
 				},
 			},
 
+			"extended_auditing_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"storage_endpoint": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"storage_account_access_key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"storage_account_access_key_is_secondary": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"retention_in_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 3285),
+						},
+
+						"audit_actions_and_groups": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validate.NoEmptyStrings,
+							},
+						},
+					},
+				},
+			},
+
 			"read_scale": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -487,6 +531,14 @@ func resourceArmSqlDatabaseCreateUpdate(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("Error setting database threat detection policy: %+v", err)
 	}
 
+	if _, ok := d.GetOk("extended_auditing_policy"); ok || d.HasChange("extended_auditing_policy") {
+		auditingClient := meta.(*ArmClient).sqlDatabaseBlobAuditingPoliciesClient
+		auditingPolicy := expandArmSqlDatabaseExtendedAuditingPolicy(d.Get("extended_auditing_policy").([]interface{}))
+		if _, err = auditingClient.CreateOrUpdate(ctx, resourceGroup, serverName, name, auditingPolicy); err != nil {
+			return fmt.Errorf("Error setting database extended auditing policy: %+v", err)
+		}
+	}
+
 	return resourceArmSqlDatabaseRead(d, meta)
 }
 
@@ -523,6 +575,14 @@ func resourceArmSqlDatabaseRead(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	auditingClient := meta.(*ArmClient).sqlDatabaseBlobAuditingPoliciesClient
+	auditingPolicy, err := auditingClient.Get(ctx, resourceGroup, serverName, name)
+	if err == nil {
+		if err := d.Set("extended_auditing_policy", flattenArmSqlDatabaseExtendedAuditingPolicy(d, auditingPolicy)); err != nil {
+			return fmt.Errorf("Error setting `extended_auditing_policy`: %+v", err)
+		}
+	}
+
 	d.Set("name", resp.Name)
 	d.Set("resource_group_name", resourceGroup)
 	if location := resp.Location; location != nil {
@@ -731,3 +791,77 @@ func expandArmSqlServerThreatDetectionPolicy(d *schema.ResourceData, location st
 
 	return &policy, nil
 }
+
+func expandArmSqlDatabaseExtendedAuditingPolicy(input []interface{}) sql.DatabaseBlobAuditingPolicy {
+	// removing the block disables auditing rather than leaving the previous policy in place
+	if len(input) == 0 || input[0] == nil {
+		return sql.DatabaseBlobAuditingPolicy{
+			DatabaseBlobAuditingPolicyProperties: &sql.DatabaseBlobAuditingPolicyProperties{
+				State: sql.BlobAuditingPolicyStateDisabled,
+			},
+		}
+	}
+
+	v := input[0].(map[string]interface{})
+
+	properties := sql.DatabaseBlobAuditingPolicyProperties{
+		State:                      sql.BlobAuditingPolicyStateEnabled,
+		StorageEndpoint:            utils.String(v["storage_endpoint"].(string)),
+		StorageAccountAccessKey:    utils.String(v["storage_account_access_key"].(string)),
+		IsStorageSecondaryKeyInUse: utils.Bool(v["storage_account_access_key_is_secondary"].(bool)),
+		RetentionDays:              utils.Int32(int32(v["retention_in_days"].(int))),
+	}
+
+	if actions := v["audit_actions_and_groups"].([]interface{}); len(actions) > 0 {
+		properties.AuditActionsAndGroups = utils.ExpandStringSlice(actions)
+	}
+
+	return sql.DatabaseBlobAuditingPolicy{
+		DatabaseBlobAuditingPolicyProperties: &properties,
+	}
+}
+
+func flattenArmSqlDatabaseExtendedAuditingPolicy(d *schema.ResourceData, policy sql.DatabaseBlobAuditingPolicy) []interface{} {
+	properties := policy.DatabaseBlobAuditingPolicyProperties
+	if properties == nil || properties.State != sql.BlobAuditingPolicyStateEnabled {
+		return []interface{}{}
+	}
+
+	storageEndpoint := ""
+	if properties.StorageEndpoint != nil {
+		storageEndpoint = *properties.StorageEndpoint
+	}
+
+	retentionDays := 0
+	if properties.RetentionDays != nil {
+		retentionDays = int(*properties.RetentionDays)
+	}
+
+	isSecondaryKeyInUse := false
+	if properties.IsStorageSecondaryKeyInUse != nil {
+		isSecondaryKeyInUse = *properties.IsStorageSecondaryKeyInUse
+	}
+
+	auditActionsAndGroups := make([]interface{}, 0)
+	if properties.AuditActionsAndGroups != nil {
+		for _, v := range *properties.AuditActionsAndGroups {
+			auditActionsAndGroups = append(auditActionsAndGroups, v)
+		}
+	}
+
+	// the API doesn't return the storage account access key, so preserve the value from the config
+	storageAccountAccessKey := ""
+	if v, ok := d.GetOk("extended_auditing_policy.0.storage_account_access_key"); ok {
+		storageAccountAccessKey = v.(string)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"storage_endpoint":                        storageEndpoint,
+			"storage_account_access_key":              storageAccountAccessKey,
+			"storage_account_access_key_is_secondary": isSecondaryKeyInUse,
+			"retention_in_days":                       retentionDays,
+			"audit_actions_and_groups":                auditActionsAndGroups,
+		},
+	}
+}
@@ -298,6 +298,42 @@ func TestAccAzureRMSqlDatabase_threatDetectionPolicy(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMSqlDatabase_extendedAuditingPolicy(t *testing.T) {
+	resourceName := "azurerm_sql_database.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSqlDatabaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMSqlDatabase_extendedAuditingPolicy(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSqlDatabaseExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "extended_auditing_policy.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "extended_auditing_policy.0.retention_in_days", "6"),
+					resource.TestCheckResourceAttr(resourceName, "extended_auditing_policy.0.audit_actions_and_groups.#", "2"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"create_mode", "extended_auditing_policy.0.storage_account_access_key"},
+			},
+			{
+				Config: testAccAzureRMSqlDatabase_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSqlDatabaseExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "extended_auditing_policy.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAzureRMSqlDatabase_readScale(t *testing.T) {
 	resourceName := "azurerm_sql_database.test"
 	ri := tf.AccRandTimeInt()
@@ -820,6 +856,50 @@ resource "azurerm_sql_database" "test" {
 `, rInt, location, rInt, rInt, rInt, state)
 }
 
+func testAccAzureRMSqlDatabase_extendedAuditingPolicy(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "test%d"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "GRS"
+}
+
+resource "azurerm_sql_server" "test" {
+  name                         = "acctestsqlserver%d"
+  resource_group_name          = "${azurerm_resource_group.test.name}"
+  location                     = "${azurerm_resource_group.test.location}"
+  version                      = "12.0"
+  administrator_login          = "mradministrator"
+  administrator_login_password = "thisIsDog11"
+}
+
+resource "azurerm_sql_database" "test" {
+  name                             = "acctestdb%d"
+  resource_group_name              = "${azurerm_resource_group.test.name}"
+  server_name                      = "${azurerm_sql_server.test.name}"
+  location                         = "${azurerm_resource_group.test.location}"
+  edition                          = "Standard"
+  collation                        = "SQL_Latin1_General_CP1_CI_AS"
+  max_size_bytes                   = "1073741824"
+  requested_service_objective_name = "S0"
+
+  extended_auditing_policy {
+    storage_endpoint           = "${azurerm_storage_account.test.primary_blob_endpoint}"
+    storage_account_access_key = "${azurerm_storage_account.test.primary_access_key}"
+    retention_in_days          = 6
+    audit_actions_and_groups   = ["SUCCESSFUL_DATABASE_AUTHENTICATION_GROUP", "FAILED_DATABASE_AUTHENTICATION_GROUP"]
+  }
+}
+`, rInt, location, rInt, rInt, rInt)
+}
+
 func testAccAzureRMSqlDatabase_readScale(rInt int, location string, readScale bool) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
@@ -0,0 +1,440 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/sql/mgmt/2015-05-01-preview/sql"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmSqlFailoverGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmSqlFailoverGroupCreate,
+		Read:   resourceArmSqlFailoverGroupRead,
+		Update: resourceArmSqlFailoverGroupUpdate,
+		Delete: resourceArmSqlFailoverGroupDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateMsSqlFailoverGroupName,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"server_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateMsSqlServerName,
+			},
+
+			"location": azure.SchemaLocationForDataSource(),
+
+			"partner_servers": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"location": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"databases": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: azure.ValidateResourceID,
+				},
+				Set: schema.HashString,
+			},
+
+			"read_write_endpoint_failover_policy": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mode": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(sql.Automatic),
+								string(sql.Manual),
+							}, false),
+						},
+
+						"grace_minutes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(60),
+						},
+					},
+				},
+			},
+
+			"readonly_endpoint_failover_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mode": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(sql.ReadOnlyEndpointFailoverPolicyDisabled),
+								string(sql.ReadOnlyEndpointFailoverPolicyEnabled),
+							}, false),
+						},
+					},
+				},
+			},
+
+			"role": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceArmSqlFailoverGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).sqlFailoverGroupsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	serverName := d.Get("server_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, serverName, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing SQL Failover Group %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_sql_failover_group", *existing.ID)
+		}
+	}
+
+	readWriteEndpoint, err := expandSqlFailoverGroupReadWriteEndpointFailoverPolicy(d.Get("read_write_endpoint_failover_policy").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	tags := d.Get("tags").(map[string]interface{})
+
+	parameters := sql.FailoverGroup{
+		FailoverGroupProperties: &sql.FailoverGroupProperties{
+			ReadWriteEndpoint: readWriteEndpoint,
+			ReadOnlyEndpoint:  expandSqlFailoverGroupReadOnlyEndpointFailoverPolicy(d.Get("readonly_endpoint_failover_policy").([]interface{})),
+			PartnerServers:    expandSqlFailoverGroupPartnerServers(d.Get("partner_servers").([]interface{})),
+			Databases:         utils.ExpandStringSlice(d.Get("databases").(*schema.Set).List()),
+		},
+		Tags: expandTags(tags),
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, serverName, name, parameters)
+	if err != nil {
+		return fmt.Errorf("Error creating SQL Failover Group %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for creation of SQL Failover Group %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, serverName, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving SQL Failover Group %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resourceGroup, err)
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("Error retrieving SQL Failover Group %q (SQL Server %q / Resource Group %q): `id` was nil", name, serverName, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmSqlFailoverGroupRead(d, meta)
+}
+
+func resourceArmSqlFailoverGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).sqlFailoverGroupsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	serverName := id.Path["servers"]
+	name := id.Path["failoverGroups"]
+
+	readWriteEndpoint, err := expandSqlFailoverGroupReadWriteEndpointFailoverPolicy(d.Get("read_write_endpoint_failover_policy").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	tags := d.Get("tags").(map[string]interface{})
+
+	// a PATCH allows Databases to be added to/removed from the Failover Group without recreating it
+	parameters := sql.FailoverGroupUpdate{
+		FailoverGroupUpdateProperties: &sql.FailoverGroupUpdateProperties{
+			ReadWriteEndpoint: readWriteEndpoint,
+			ReadOnlyEndpoint:  expandSqlFailoverGroupReadOnlyEndpointFailoverPolicy(d.Get("readonly_endpoint_failover_policy").([]interface{})),
+			Databases:         utils.ExpandStringSlice(d.Get("databases").(*schema.Set).List()),
+		},
+		Tags: expandTags(tags),
+	}
+
+	future, err := client.Update(ctx, resourceGroup, serverName, name, parameters)
+	if err != nil {
+		return fmt.Errorf("Error updating SQL Failover Group %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for update of SQL Failover Group %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resourceGroup, err)
+	}
+
+	return resourceArmSqlFailoverGroupRead(d, meta)
+}
+
+func resourceArmSqlFailoverGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).sqlFailoverGroupsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	serverName := id.Path["servers"]
+	name := id.Path["failoverGroups"]
+
+	resp, err := client.Get(ctx, resourceGroup, serverName, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] SQL Failover Group %q was not found on SQL Server %q (Resource Group %q) - removing from state!", name, serverName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving SQL Failover Group %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("server_name", serverName)
+	if location := resp.Location; location != nil {
+		d.Set("location", azure.NormalizeLocation(*location))
+	}
+
+	if props := resp.FailoverGroupProperties; props != nil {
+		d.Set("role", string(props.ReplicationRole))
+
+		if err := d.Set("read_write_endpoint_failover_policy", flattenSqlFailoverGroupReadWriteEndpointFailoverPolicy(props.ReadWriteEndpoint)); err != nil {
+			return fmt.Errorf("Error setting `read_write_endpoint_failover_policy`: %+v", err)
+		}
+
+		if err := d.Set("readonly_endpoint_failover_policy", flattenSqlFailoverGroupReadOnlyEndpointFailoverPolicy(props.ReadOnlyEndpoint)); err != nil {
+			return fmt.Errorf("Error setting `readonly_endpoint_failover_policy`: %+v", err)
+		}
+
+		if err := d.Set("partner_servers", flattenSqlFailoverGroupPartnerServers(props.PartnerServers)); err != nil {
+			return fmt.Errorf("Error setting `partner_servers`: %+v", err)
+		}
+
+		if err := d.Set("databases", flattenSqlFailoverGroupDatabases(props.Databases)); err != nil {
+			return fmt.Errorf("Error setting `databases`: %+v", err)
+		}
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmSqlFailoverGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).sqlFailoverGroupsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	serverName := id.Path["servers"]
+	name := id.Path["failoverGroups"]
+
+	future, err := client.Delete(ctx, resourceGroup, serverName, name)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting SQL Failover Group %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for deletion of SQL Failover Group %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func expandSqlFailoverGroupReadWriteEndpointFailoverPolicy(input []interface{}) (*sql.FailoverGroupReadWriteEndpoint, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, nil
+	}
+
+	v := input[0].(map[string]interface{})
+	mode := sql.ReadWriteEndpointFailoverPolicy(v["mode"].(string))
+	graceMinutes := v["grace_minutes"].(int)
+
+	endpoint := sql.FailoverGroupReadWriteEndpoint{
+		FailoverPolicy: mode,
+	}
+
+	if mode == sql.Automatic {
+		if graceMinutes == 0 {
+			return nil, fmt.Errorf("Error: `grace_minutes` must be specified when the `read_write_endpoint_failover_policy` `mode` is `Automatic`")
+		}
+
+		endpoint.FailoverWithDataLossGracePeriodMinutes = utils.Int32(int32(graceMinutes))
+	} else if graceMinutes != 0 {
+		return nil, fmt.Errorf("Error: `grace_minutes` can only be specified when the `read_write_endpoint_failover_policy` `mode` is `Automatic`")
+	}
+
+	return &endpoint, nil
+}
+
+func flattenSqlFailoverGroupReadWriteEndpointFailoverPolicy(input *sql.FailoverGroupReadWriteEndpoint) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	graceMinutes := 0
+	if input.FailoverWithDataLossGracePeriodMinutes != nil {
+		graceMinutes = int(*input.FailoverWithDataLossGracePeriodMinutes)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"mode":          string(input.FailoverPolicy),
+			"grace_minutes": graceMinutes,
+		},
+	}
+}
+
+func expandSqlFailoverGroupReadOnlyEndpointFailoverPolicy(input []interface{}) *sql.FailoverGroupReadOnlyEndpoint {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+	return &sql.FailoverGroupReadOnlyEndpoint{
+		FailoverPolicy: sql.ReadOnlyEndpointFailoverPolicy(v["mode"].(string)),
+	}
+}
+
+func flattenSqlFailoverGroupReadOnlyEndpointFailoverPolicy(input *sql.FailoverGroupReadOnlyEndpoint) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"mode": string(input.FailoverPolicy),
+		},
+	}
+}
+
+func expandSqlFailoverGroupPartnerServers(input []interface{}) *[]sql.PartnerInfo {
+	partners := make([]sql.PartnerInfo, 0)
+
+	for _, raw := range input {
+		v := raw.(map[string]interface{})
+		partners = append(partners, sql.PartnerInfo{
+			ID: utils.String(v["id"].(string)),
+		})
+	}
+
+	return &partners
+}
+
+func flattenSqlFailoverGroupPartnerServers(input *[]sql.PartnerInfo) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, partner := range *input {
+		id := ""
+		if partner.ID != nil {
+			id = *partner.ID
+		}
+
+		location := ""
+		if partner.Location != nil {
+			location = azure.NormalizeLocation(*partner.Location)
+		}
+
+		results = append(results, map[string]interface{}{
+			"id":       id,
+			"location": location,
+			"role":     string(partner.ReplicationRole),
+		})
+	}
+
+	return results
+}
+
+func flattenSqlFailoverGroupDatabases(input *[]string) *schema.Set {
+	databases := &schema.Set{F: schema.HashString}
+	if input == nil {
+		return databases
+	}
+
+	for _, database := range *input {
+		databases.Add(database)
+	}
+
+	return databases
+}
@@ -0,0 +1,288 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAccAzureRMSqlFailoverGroup_basic(t *testing.T) {
+	resourceName := "azurerm_sql_failover_group.test"
+	ri := tf.AccRandTimeInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSqlFailoverGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMSqlFailoverGroup_basic(ri, testLocation(), testAltLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSqlFailoverGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "role", "Primary"),
+					resource.TestCheckResourceAttr(resourceName, "partner_servers.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "partner_servers.0.role", "Secondary"),
+					resource.TestCheckResourceAttr(resourceName, "databases.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "read_write_endpoint_failover_policy.0.mode", "Automatic"),
+					resource.TestCheckResourceAttr(resourceName, "read_write_endpoint_failover_policy.0.grace_minutes", "60"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMSqlFailoverGroup_requiresImport(t *testing.T) {
+	if !requireResourcesToBeImported {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azurerm_sql_failover_group.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+	altLocation := testAltLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSqlFailoverGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMSqlFailoverGroup_basic(ri, location, altLocation),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSqlFailoverGroupExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccAzureRMSqlFailoverGroup_requiresImport(ri, location, altLocation),
+				ExpectError: testRequiresImportError("azurerm_sql_failover_group"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMSqlFailoverGroup_update(t *testing.T) {
+	resourceName := "azurerm_sql_failover_group.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+	altLocation := testAltLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSqlFailoverGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMSqlFailoverGroup_basic(ri, location, altLocation),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSqlFailoverGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "databases.#", "1"),
+				),
+			},
+			{
+				Config: testAccAzureRMSqlFailoverGroup_updated(ri, location, altLocation),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSqlFailoverGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "databases.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "read_write_endpoint_failover_policy.0.mode", "Manual"),
+					resource.TestCheckResourceAttr(resourceName, "readonly_endpoint_failover_policy.0.mode", "Enabled"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckAzureRMSqlFailoverGroupExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		serverName := rs.Primary.Attributes["server_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).sqlFailoverGroupsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, serverName, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: SQL Failover Group %q (SQL Server %q / Resource Group %q) does not exist", name, serverName, resourceGroup)
+			}
+
+			return fmt.Errorf("Bad: Get on sqlFailoverGroupsClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMSqlFailoverGroupDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).sqlFailoverGroupsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_sql_failover_group" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		serverName := rs.Primary.Attributes["server_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := client.Get(ctx, resourceGroup, serverName, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return nil
+			}
+
+			return err
+		}
+
+		return fmt.Errorf("SQL Failover Group %q (SQL Server %q / Resource Group %q) still exists", name, serverName, resourceGroup)
+	}
+
+	return nil
+}
+
+func testAccAzureRMSqlFailoverGroup_template(rInt int, location, altLocation string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_sql_server" "test_primary" {
+  name                         = "acctestsqlserver%d-primary"
+  resource_group_name          = "${azurerm_resource_group.test.name}"
+  location                     = "${azurerm_resource_group.test.location}"
+  version                      = "12.0"
+  administrator_login          = "mradministrator"
+  administrator_login_password = "thisIsDog11"
+}
+
+resource "azurerm_sql_server" "test_secondary" {
+  name                         = "acctestsqlserver%d-secondary"
+  resource_group_name          = "${azurerm_resource_group.test.name}"
+  location                     = "%s"
+  version                      = "12.0"
+  administrator_login          = "mradministrator"
+  administrator_login_password = "thisIsDog11"
+}
+
+resource "azurerm_sql_database" "test" {
+  name                             = "acctestdb%d"
+  resource_group_name              = "${azurerm_resource_group.test.name}"
+  server_name                      = "${azurerm_sql_server.test_primary.name}"
+  location                         = "${azurerm_resource_group.test.location}"
+  edition                          = "Standard"
+  collation                        = "SQL_Latin1_General_CP1_CI_AS"
+  max_size_bytes                   = "1073741824"
+  requested_service_objective_name = "S0"
+}
+
+resource "azurerm_sql_database" "test2" {
+  name                             = "acctestdb%d-2"
+  resource_group_name              = "${azurerm_resource_group.test.name}"
+  server_name                      = "${azurerm_sql_server.test_primary.name}"
+  location                         = "${azurerm_resource_group.test.location}"
+  edition                          = "Standard"
+  collation                        = "SQL_Latin1_General_CP1_CI_AS"
+  max_size_bytes                   = "1073741824"
+  requested_service_objective_name = "S0"
+}
+`, rInt, location, rInt, rInt, altLocation, rInt, rInt)
+}
+
+func testAccAzureRMSqlFailoverGroup_basic(rInt int, location, altLocation string) string {
+	template := testAccAzureRMSqlFailoverGroup_template(rInt, location, altLocation)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sql_failover_group" "test" {
+  name                = "acctestsfg%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  server_name         = "${azurerm_sql_server.test_primary.name}"
+  databases           = ["${azurerm_sql_database.test.id}"]
+
+  partner_servers {
+    id = "${azurerm_sql_server.test_secondary.id}"
+  }
+
+  read_write_endpoint_failover_policy {
+    mode          = "Automatic"
+    grace_minutes = 60
+  }
+}
+`, template, rInt)
+}
+
+func testAccAzureRMSqlFailoverGroup_requiresImport(rInt int, location, altLocation string) string {
+	template := testAccAzureRMSqlFailoverGroup_basic(rInt, location, altLocation)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sql_failover_group" "import" {
+  name                = "${azurerm_sql_failover_group.test.name}"
+  resource_group_name = "${azurerm_sql_failover_group.test.resource_group_name}"
+  server_name         = "${azurerm_sql_failover_group.test.server_name}"
+  databases           = ["${azurerm_sql_database.test.id}"]
+
+  partner_servers {
+    id = "${azurerm_sql_server.test_secondary.id}"
+  }
+
+  read_write_endpoint_failover_policy {
+    mode          = "Automatic"
+    grace_minutes = 60
+  }
+}
+`, template)
+}
+
+func testAccAzureRMSqlFailoverGroup_updated(rInt int, location, altLocation string) string {
+	template := testAccAzureRMSqlFailoverGroup_template(rInt, location, altLocation)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sql_failover_group" "test" {
+  name                = "acctestsfg%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  server_name         = "${azurerm_sql_server.test_primary.name}"
+  databases           = ["${azurerm_sql_database.test.id}", "${azurerm_sql_database.test2.id}"]
+
+  partner_servers {
+    id = "${azurerm_sql_server.test_secondary.id}"
+  }
+
+  read_write_endpoint_failover_policy {
+    mode = "Manual"
+  }
+
+  readonly_endpoint_failover_policy {
+    mode = "Enabled"
+  }
+
+  tags = {
+    environment = "Production"
+  }
+}
+`, template, rInt)
+}
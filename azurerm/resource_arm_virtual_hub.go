@@ -0,0 +1,380 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmVirtualHub() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmVirtualHubCreateUpdate,
+		Read:   resourceArmVirtualHubRead,
+		Update: resourceArmVirtualHubCreateUpdate,
+		Delete: resourceArmVirtualHubDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"location": azure.SchemaLocation(),
+
+			"virtual_wan_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"address_prefix": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.CIDR,
+			},
+
+			"route": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address_prefixes": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validate.CIDR,
+							},
+						},
+
+						"next_hop_ip_address": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.IPv4Address,
+						},
+					},
+				},
+			},
+
+			"virtual_network_connection": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"remote_virtual_network_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"allow_hub_to_remote_vnet_transit": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"allow_remote_vnet_to_use_hub_vnet_gateways": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"internet_security_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+
+			"vpn_gateway_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceArmVirtualHubCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).virtualHubClient
+	ctx := meta.(*ArmClient).StopContext
+
+	log.Printf("[INFO] preparing arguments for Virtual Hub creation.")
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing Virtual Hub %q (Resource Group %q): %s", name, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_virtual_hub", *existing.ID)
+		}
+	}
+
+	location := azure.NormalizeLocation(d.Get("location").(string))
+	addressPrefix := d.Get("address_prefix").(string)
+	virtualWanId := d.Get("virtual_wan_id").(string)
+	tags := d.Get("tags").(map[string]interface{})
+
+	hub := network.VirtualHub{
+		Location: utils.String(location),
+		Tags:     expandTags(tags),
+		VirtualHubProperties: &network.VirtualHubProperties{
+			AddressPrefix: utils.String(addressPrefix),
+			VirtualWan: &network.SubResource{
+				ID: utils.String(virtualWanId),
+			},
+			RouteTable:                expandArmVirtualHubRoutes(d.Get("route").([]interface{})),
+			VirtualNetworkConnections: expandArmVirtualHubVirtualNetworkConnections(d.Get("virtual_network_connection").([]interface{})),
+		},
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, name, hub)
+	if err != nil {
+		return fmt.Errorf("Error creating/updating Virtual Hub %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for the creation/update of Virtual Hub %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Virtual Hub %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read Virtual Hub %q (Resource Group %q) ID", name, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmVirtualHubRead(d, meta)
+}
+
+func resourceArmVirtualHubRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).virtualHubClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["virtualHubs"]
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Virtual Hub %q was not found in Resource Group %q - removing from state", name, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Virtual Hub %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+	if location := resp.Location; location != nil {
+		d.Set("location", azure.NormalizeLocation(*location))
+	}
+
+	if props := resp.VirtualHubProperties; props != nil {
+		d.Set("address_prefix", props.AddressPrefix)
+
+		virtualWanId := ""
+		if props.VirtualWan != nil && props.VirtualWan.ID != nil {
+			virtualWanId = *props.VirtualWan.ID
+		}
+		d.Set("virtual_wan_id", virtualWanId)
+
+		vpnGatewayId := ""
+		if props.VpnGateway != nil && props.VpnGateway.ID != nil {
+			vpnGatewayId = *props.VpnGateway.ID
+		}
+		d.Set("vpn_gateway_id", vpnGatewayId)
+
+		if err := d.Set("route", flattenArmVirtualHubRoutes(props.RouteTable)); err != nil {
+			return fmt.Errorf("Error setting `route`: %+v", err)
+		}
+
+		if err := d.Set("virtual_network_connection", flattenArmVirtualHubVirtualNetworkConnections(props.VirtualNetworkConnections)); err != nil {
+			return fmt.Errorf("Error setting `virtual_network_connection`: %+v", err)
+		}
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmVirtualHubDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).virtualHubClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["virtualHubs"]
+
+	future, err := client.Delete(ctx, resourceGroup, name)
+	if err != nil {
+		// deleted outside of Terraform
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting Virtual Hub %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for the deletion of Virtual Hub %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func expandArmVirtualHubRoutes(input []interface{}) *network.VirtualHubRouteTable {
+	// an empty route table is sent (rather than omitted) so that removing all routes clears them
+	routes := make([]network.VirtualHubRoute, 0)
+	for _, v := range input {
+		route := v.(map[string]interface{})
+
+		addressPrefixes := make([]string, 0)
+		for _, prefix := range route["address_prefixes"].([]interface{}) {
+			addressPrefixes = append(addressPrefixes, prefix.(string))
+		}
+
+		routes = append(routes, network.VirtualHubRoute{
+			AddressPrefixes:  &addressPrefixes,
+			NextHopIPAddress: utils.String(route["next_hop_ip_address"].(string)),
+		})
+	}
+
+	return &network.VirtualHubRouteTable{
+		Routes: &routes,
+	}
+}
+
+func expandArmVirtualHubVirtualNetworkConnections(input []interface{}) *[]network.HubVirtualNetworkConnection {
+	connections := make([]network.HubVirtualNetworkConnection, 0)
+
+	for _, v := range input {
+		connection := v.(map[string]interface{})
+
+		connections = append(connections, network.HubVirtualNetworkConnection{
+			Name: utils.String(connection["name"].(string)),
+			HubVirtualNetworkConnectionProperties: &network.HubVirtualNetworkConnectionProperties{
+				RemoteVirtualNetwork: &network.SubResource{
+					ID: utils.String(connection["remote_virtual_network_id"].(string)),
+				},
+				AllowHubToRemoteVnetTransit:         utils.Bool(connection["allow_hub_to_remote_vnet_transit"].(bool)),
+				AllowRemoteVnetToUseHubVnetGateways: utils.Bool(connection["allow_remote_vnet_to_use_hub_vnet_gateways"].(bool)),
+				EnableInternetSecurity:              utils.Bool(connection["internet_security_enabled"].(bool)),
+			},
+		})
+	}
+
+	return &connections
+}
+
+func flattenArmVirtualHubRoutes(input *network.VirtualHubRouteTable) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil || input.Routes == nil {
+		return results
+	}
+
+	for _, route := range *input.Routes {
+		addressPrefixes := make([]interface{}, 0)
+		if route.AddressPrefixes != nil {
+			for _, prefix := range *route.AddressPrefixes {
+				addressPrefixes = append(addressPrefixes, prefix)
+			}
+		}
+
+		nextHopIPAddress := ""
+		if route.NextHopIPAddress != nil {
+			nextHopIPAddress = *route.NextHopIPAddress
+		}
+
+		results = append(results, map[string]interface{}{
+			"address_prefixes":    addressPrefixes,
+			"next_hop_ip_address": nextHopIPAddress,
+		})
+	}
+
+	return results
+}
+
+func flattenArmVirtualHubVirtualNetworkConnections(input *[]network.HubVirtualNetworkConnection) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, connection := range *input {
+		result := make(map[string]interface{})
+
+		if connection.Name != nil {
+			result["name"] = *connection.Name
+		}
+
+		if props := connection.HubVirtualNetworkConnectionProperties; props != nil {
+			if props.RemoteVirtualNetwork != nil && props.RemoteVirtualNetwork.ID != nil {
+				result["remote_virtual_network_id"] = *props.RemoteVirtualNetwork.ID
+			}
+
+			if props.AllowHubToRemoteVnetTransit != nil {
+				result["allow_hub_to_remote_vnet_transit"] = *props.AllowHubToRemoteVnetTransit
+			}
+
+			if props.AllowRemoteVnetToUseHubVnetGateways != nil {
+				result["allow_remote_vnet_to_use_hub_vnet_gateways"] = *props.AllowRemoteVnetToUseHubVnetGateways
+			}
+
+			if props.EnableInternetSecurity != nil {
+				result["internet_security_enabled"] = *props.EnableInternetSecurity
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
@@ -12,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	intStor "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
@@ -80,6 +82,14 @@ func resourceArmVirtualMachine() *schema.Resource {
 				ConflictsWith: []string{"zones"},
 			},
 
+			"proximity_placement_group_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     azure.ValidateResourceID,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+
 			"identity": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -265,6 +275,30 @@ func resourceArmVirtualMachine() *schema.Resource {
 				Default:  false,
 			},
 
+			"power_state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					virtualMachinePowerStateRunning,
+					virtualMachinePowerStateStopped,
+					virtualMachinePowerStateDeallocated,
+				}, false),
+			},
+
+			"graceful_shutdown": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"graceful_shutdown_timeout_in_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(1, 60),
+			},
+
 			"storage_data_disk": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -648,6 +682,12 @@ func resourceArmVirtualMachineCreateUpdate(d *schema.ResourceData, meta interfac
 		properties.AvailabilitySet = &availSet
 	}
 
+	if v, ok := d.GetOk("proximity_placement_group_id"); ok {
+		properties.ProximityPlacementGroup = &compute.SubResource{
+			ID: utils.String(v.(string)),
+		}
+	}
+
 	vm := compute.VirtualMachine{
 		Name:                     &name,
 		Location:                 &location,
@@ -692,6 +732,12 @@ func resourceArmVirtualMachineCreateUpdate(d *schema.ResourceData, meta interfac
 
 	d.SetId(*read.ID)
 
+	if v, ok := d.GetOk("power_state"); ok {
+		if err := setVirtualMachinePowerState(ctx, &client, resGroup, name, v.(string)); err != nil {
+			return err
+		}
+	}
+
 	ipAddress, err := determineVirtualMachineIPAddress(ctx, meta, read.VirtualMachineProperties)
 	if err != nil {
 		return fmt.Errorf("Error determining IP Address for Virtual Machine %q (Resource Group %q): %+v", name, resGroup, err)
@@ -740,6 +786,12 @@ func resourceArmVirtualMachineRead(d *schema.ResourceData, meta interface{}) err
 		d.Set("location", azure.NormalizeLocation(*location))
 	}
 
+	powerState, err := getVirtualMachinePowerState(ctx, &vmClient, resGroup, name)
+	if err != nil {
+		return err
+	}
+	d.Set("power_state", powerState)
+
 	if err := d.Set("plan", flattenAzureRmVirtualMachinePlan(resp.Plan)); err != nil {
 		return fmt.Errorf("Error setting `plan`: %#v", err)
 	}
@@ -754,6 +806,12 @@ func resourceArmVirtualMachineRead(d *schema.ResourceData, meta interface{}) err
 			d.Set("availability_set_id", strings.ToLower(*availabilitySet.ID))
 		}
 
+		proximityPlacementGroupId := ""
+		if props.ProximityPlacementGroup != nil && props.ProximityPlacementGroup.ID != nil {
+			proximityPlacementGroupId = *props.ProximityPlacementGroup.ID
+		}
+		d.Set("proximity_placement_group_id", proximityPlacementGroupId)
+
 		if profile := props.HardwareProfile; profile != nil {
 			d.Set("vm_size", profile.VMSize)
 		}
@@ -854,6 +912,13 @@ func resourceArmVirtualMachineDelete(d *schema.ResourceData, meta interface{}) e
 		return fmt.Errorf("Error retrieving Virtual Machine %q (Resource Group %q): %s", name, resGroup, err)
 	}
 
+	if d.Get("graceful_shutdown").(bool) {
+		timeout := time.Duration(d.Get("graceful_shutdown_timeout_in_minutes").(int)) * time.Minute
+		if err := shutdownVirtualMachineGracefully(ctx, &client, resGroup, name, timeout); err != nil {
+			return err
+		}
+	}
+
 	future, err := client.Delete(ctx, resGroup, name)
 	if err != nil {
 		return fmt.Errorf("Error deleting Virtual Machine %q (Resource Group %q): %s", name, resGroup, err)
@@ -930,6 +995,130 @@ func resourceArmVirtualMachineDelete(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
+const (
+	virtualMachinePowerStateRunning     = "running"
+	virtualMachinePowerStateStopped     = "stopped"
+	virtualMachinePowerStateDeallocated = "deallocated"
+)
+
+// getVirtualMachinePowerState returns the Power State of the Virtual Machine from its Instance View (e.g. `running`),
+// treating a Virtual Machine which is transitioning between states as being in the state it's transitioning to
+func getVirtualMachinePowerState(ctx context.Context, client *compute.VirtualMachinesClient, resourceGroup, name string) (string, error) {
+	instanceView, err := client.InstanceView(ctx, resourceGroup, name)
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving Instance View for Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	powerState := ""
+	if statuses := instanceView.Statuses; statuses != nil {
+		for _, status := range *statuses {
+			if status.Code == nil {
+				continue
+			}
+
+			code := strings.ToLower(*status.Code)
+			if strings.HasPrefix(code, "powerstate/") {
+				powerState = strings.TrimPrefix(code, "powerstate/")
+			}
+		}
+	}
+
+	switch powerState {
+	case "starting":
+		return virtualMachinePowerStateRunning, nil
+	case "stopping":
+		return virtualMachinePowerStateStopped, nil
+	case "deallocating":
+		return virtualMachinePowerStateDeallocated, nil
+	}
+
+	return powerState, nil
+}
+
+// setVirtualMachinePowerState starts, stops or deallocates the Virtual Machine if it's not already in the desired Power State
+func setVirtualMachinePowerState(ctx context.Context, client *compute.VirtualMachinesClient, resourceGroup, name, desired string) error {
+	current, err := getVirtualMachinePowerState(ctx, client, resourceGroup, name)
+	if err != nil {
+		return err
+	}
+
+	if current == desired {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Changing the Power State of Virtual Machine %q (Resource Group %q) from %q to %q..", name, resourceGroup, current, desired)
+	switch desired {
+	case virtualMachinePowerStateRunning:
+		future, err := client.Start(ctx, resourceGroup, name)
+		if err != nil {
+			return fmt.Errorf("Error starting Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for start of Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+	case virtualMachinePowerStateStopped:
+		future, err := client.PowerOff(ctx, resourceGroup, name)
+		if err != nil {
+			return fmt.Errorf("Error stopping Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for Virtual Machine %q (Resource Group %q) to stop: %+v", name, resourceGroup, err)
+		}
+
+	case virtualMachinePowerStateDeallocated:
+		future, err := client.Deallocate(ctx, resourceGroup, name)
+		if err != nil {
+			return fmt.Errorf("Error deallocating Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for deallocation of Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+	default:
+		return fmt.Errorf("Unsupported Power State %q for Virtual Machine %q (Resource Group %q)", desired, name, resourceGroup)
+	}
+	log.Printf("[DEBUG] Changed the Power State of Virtual Machine %q (Resource Group %q) to %q.", name, resourceGroup, desired)
+
+	return nil
+}
+
+// shutdownVirtualMachineGracefully shuts down a running Virtual Machine from within the Guest OS, giving
+// applications the chance to drain - after the timeout we carry on regardless, since the VM's being deleted
+func shutdownVirtualMachineGracefully(ctx context.Context, client *compute.VirtualMachinesClient, resourceGroup, name string, timeout time.Duration) error {
+	powerState, err := getVirtualMachinePowerState(ctx, client, resourceGroup, name)
+	if err != nil {
+		return err
+	}
+
+	if powerState != virtualMachinePowerStateRunning {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Shutting down Virtual Machine %q (Resource Group %q) (timeout %s)..", name, resourceGroup, timeout)
+	future, err := client.PowerOff(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error shutting down Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := future.WaitForCompletionRef(shutdownCtx, client.Client); err != nil {
+		if shutdownCtx.Err() == context.DeadlineExceeded {
+			log.Printf("[WARN] Virtual Machine %q (Resource Group %q) didn't shut down within %s - continuing with deletion", name, resourceGroup, timeout)
+			return nil
+		}
+
+		return fmt.Errorf("Error waiting for shutdown of Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	log.Printf("[DEBUG] Shut down Virtual Machine %q (Resource Group %q).", name, resourceGroup)
+
+	return nil
+}
+
 func resourceArmVirtualMachineDeleteVhd(ctx context.Context, storageClient *intStor.Client, vhd *compute.VirtualHardDisk) error {
 	if vhd == nil {
 		return fmt.Errorf("`vhd` was nil`")
@@ -2,23 +2,29 @@ package azurerm
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/structure"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+var virtualMachineScaleSetResourceName = "azurerm_virtual_machine_scale_set"
+
 func resourceArmVirtualMachineScaleSet() *schema.Resource {
 	return &schema.Resource{
 		Create:        resourceArmVirtualMachineScaleSetCreateUpdate,
@@ -29,7 +35,7 @@ func resourceArmVirtualMachineScaleSet() *schema.Resource {
 		SchemaVersion: 1,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceArmVirtualMachineScaleSetImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -178,6 +184,12 @@ func resourceArmVirtualMachineScaleSet() *schema.Resource {
 				DiffSuppressFunc: azureRmVirtualMachineScaleSetSuppressRollingUpgradePolicyDiff,
 			},
 
+			"upgrade_instances_on_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"overprovision": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -191,6 +203,14 @@ func resourceArmVirtualMachineScaleSet() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"proximity_placement_group_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     azure.ValidateResourceID,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+
 			"priority": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -211,6 +231,11 @@ func resourceArmVirtualMachineScaleSet() *schema.Resource {
 				}, false),
 			},
 
+			"deallocated_instance_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
 			"os_profile": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -761,6 +786,10 @@ func resourceArmVirtualMachineScaleSetCreateUpdate(d *schema.ResourceData, meta
 	name := d.Get("name").(string)
 	resGroup := d.Get("resource_group_name").(string)
 
+	// the `azurerm_virtual_machine_scale_set_extension` resource modifies the Scale Set too
+	azureRMLockByName(name, virtualMachineScaleSetResourceName)
+	defer azureRMUnlockByName(name, virtualMachineScaleSetResourceName)
+
 	if requireResourcesToBeImported && d.IsNewResource() {
 		existing, err := client.Get(ctx, resGroup, name)
 		if err != nil {
@@ -823,23 +852,42 @@ func resourceArmVirtualMachineScaleSetCreateUpdate(d *schema.ResourceData, meta
 	priority := d.Get("priority").(string)
 	evictionPolicy := d.Get("eviction_policy").(string)
 
+	// when we're upgrading the instances ourselves the `rolling_upgrade_policy` only sizes our batches,
+	// so it's not sent to Azure - which only accepts a non-default policy in `Rolling` mode
+	var rollingUpgradePolicy *compute.RollingUpgradePolicy
+	if !azureRmVirtualMachineScaleSetUpgradesInstancesManually(d) {
+		rollingUpgradePolicy = expandAzureRmRollingUpgradePolicy(d)
+	}
+
 	scaleSetProps := compute.VirtualMachineScaleSetProperties{
 		UpgradePolicy: &compute.UpgradePolicy{
 			Mode:                 compute.UpgradeMode(upgradePolicy),
 			AutomaticOSUpgrade:   utils.Bool(automaticOsUpgrade),
-			RollingUpgradePolicy: expandAzureRmRollingUpgradePolicy(d),
+			RollingUpgradePolicy: rollingUpgradePolicy,
 		},
 		VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
-			NetworkProfile:   expandAzureRmVirtualMachineScaleSetNetworkProfile(d),
-			StorageProfile:   &storageProfile,
-			OsProfile:        osProfile,
-			ExtensionProfile: extensions,
-			Priority:         compute.VirtualMachinePriorityTypes(priority),
+			NetworkProfile: expandAzureRmVirtualMachineScaleSetNetworkProfile(d),
+			StorageProfile: &storageProfile,
+			OsProfile:      osProfile,
+			Priority:       compute.VirtualMachinePriorityTypes(priority),
 		},
 		Overprovision:        &overprovision,
 		SinglePlacementGroup: &singlePlacementGroup,
 	}
 
+	// the Extension Profile is only sent on creation, since sending it during an update would remove the extensions
+	// managed by the `azurerm_virtual_machine_scale_set_extension` resource - instead changes to the in-line
+	// extensions are applied through the Extensions API once the Scale Set has been updated
+	if d.IsNewResource() {
+		scaleSetProps.VirtualMachineProfile.ExtensionProfile = extensions
+	}
+
+	if v, ok := d.GetOk("proximity_placement_group_id"); ok {
+		scaleSetProps.ProximityPlacementGroup = &compute.SubResource{
+			ID: utils.String(v.(string)),
+		}
+	}
+
 	if strings.EqualFold(priority, string(compute.Low)) {
 		scaleSetProps.VirtualMachineProfile.EvictionPolicy = compute.VirtualMachineEvictionPolicyTypes(evictionPolicy)
 	}
@@ -890,6 +938,19 @@ func resourceArmVirtualMachineScaleSetCreateUpdate(d *schema.ResourceData, meta
 		return err
 	}
 
+	if !d.IsNewResource() && d.HasChange("extension") {
+		if err := resourceArmVirtualMachineScaleSetUpdateExtensions(d, meta, resGroup, name); err != nil {
+			return err
+		}
+	}
+
+	// in Manual mode the existing instances aren't updated to the latest model, so (when opted-in) we do this ourselves
+	if !d.IsNewResource() && d.Get("upgrade_instances_on_change").(bool) && strings.EqualFold(upgradePolicy, string(compute.Manual)) {
+		if err := resourceArmVirtualMachineScaleSetUpgradeInstances(d, meta, resGroup, name); err != nil {
+			return fmt.Errorf("Error upgrading instances of Virtual Machine Scale Set %q (Resource Group %q) to the latest model: %+v", name, resGroup, err)
+		}
+	}
+
 	read, err := client.Get(ctx, resGroup, name)
 	if err != nil {
 		return err
@@ -931,6 +992,12 @@ func resourceArmVirtualMachineScaleSetRead(d *schema.ResourceData, meta interfac
 	}
 	d.Set("zones", resp.Zones)
 
+	deallocatedInstanceCount, err := resourceArmVirtualMachineScaleSetDeallocatedInstanceCount(meta, resGroup, name, resp)
+	if err != nil {
+		return err
+	}
+	d.Set("deallocated_instance_count", deallocatedInstanceCount)
+
 	if err := d.Set("sku", flattenAzureRmVirtualMachineScaleSetSku(resp.Sku)); err != nil {
 		return fmt.Errorf("[DEBUG] Error setting `sku`: %#v", err)
 	}
@@ -945,7 +1012,9 @@ func resourceArmVirtualMachineScaleSetRead(d *schema.ResourceData, meta interfac
 			d.Set("upgrade_policy_mode", upgradePolicy.Mode)
 			d.Set("automatic_os_upgrade", upgradePolicy.AutomaticOSUpgrade)
 
-			if rollingUpgradePolicy := upgradePolicy.RollingUpgradePolicy; rollingUpgradePolicy != nil {
+			// the `rolling_upgrade_policy` isn't sent to Azure when we're upgrading the instances ourselves
+			// (see above) - so the value from the config is kept rather than being overwritten by the API's default
+			if rollingUpgradePolicy := upgradePolicy.RollingUpgradePolicy; rollingUpgradePolicy != nil && !azureRmVirtualMachineScaleSetUpgradesInstancesManually(d) {
 				if err := d.Set("rolling_upgrade_policy", flattenAzureRmVirtualMachineScaleSetRollingUpgradePolicy(rollingUpgradePolicy)); err != nil {
 					return fmt.Errorf("[DEBUG] Error setting Virtual Machine Scale Set Rolling Upgrade Policy error: %#v", err)
 				}
@@ -954,6 +1023,12 @@ func resourceArmVirtualMachineScaleSetRead(d *schema.ResourceData, meta interfac
 		d.Set("overprovision", properties.Overprovision)
 		d.Set("single_placement_group", properties.SinglePlacementGroup)
 
+		proximityPlacementGroupId := ""
+		if properties.ProximityPlacementGroup != nil && properties.ProximityPlacementGroup.ID != nil {
+			proximityPlacementGroupId = *properties.ProximityPlacementGroup.ID
+		}
+		d.Set("proximity_placement_group_id", proximityPlacementGroupId)
+
 		if profile := properties.VirtualMachineProfile; profile != nil {
 			d.Set("license_type", profile.LicenseType)
 			d.Set("priority", string(profile.Priority))
@@ -1034,10 +1109,25 @@ func resourceArmVirtualMachineScaleSetRead(d *schema.ResourceData, meta interfac
 			}
 
 			if extensionProfile := properties.VirtualMachineProfile.ExtensionProfile; extensionProfile != nil {
-				extension, err := flattenAzureRmVirtualMachineScaleSetExtensionProfile(extensionProfile)
+				extensions, err := flattenAzureRmVirtualMachineScaleSetExtensionProfile(extensionProfile)
 				if err != nil {
 					return fmt.Errorf("[DEBUG] Error setting Virtual Machine Scale Set Extension Profile error: %#v", err)
 				}
+
+				// only the extensions defined in-line are tracked here, so that any managed by the
+				// `azurerm_virtual_machine_scale_set_extension` resource don't show up as a diff
+				inlineExtensionNames := make(map[string]bool)
+				for _, v := range d.Get("extension").(*schema.Set).List() {
+					inlineExtensionNames[v.(map[string]interface{})["name"].(string)] = true
+				}
+
+				extension := make([]map[string]interface{}, 0)
+				for _, v := range extensions {
+					if inlineExtensionNames[v["name"].(string)] {
+						extension = append(extension, v)
+					}
+				}
+
 				if err := d.Set("extension", extension); err != nil {
 					return fmt.Errorf("[DEBUG] Error setting `extension`: %#v", err)
 				}
@@ -1058,6 +1148,39 @@ func resourceArmVirtualMachineScaleSetRead(d *schema.ResourceData, meta interfac
 	return nil
 }
 
+func resourceArmVirtualMachineScaleSetImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*ArmClient).vmScaleSetClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	resGroup := id.ResourceGroup
+	name := id.Path["virtualMachineScaleSets"]
+
+	resp, err := client.Get(ctx, resGroup, name)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	// Read only tracks the extensions which are defined in-line - since there's no way to tell these
+	// apart from those managed by the `azurerm_virtual_machine_scale_set_extension` resource, import them all
+	if props := resp.VirtualMachineScaleSetProperties; props != nil && props.VirtualMachineProfile != nil {
+		if extensionProfile := props.VirtualMachineProfile.ExtensionProfile; extensionProfile != nil {
+			extensions, err := flattenAzureRmVirtualMachineScaleSetExtensionProfile(extensionProfile)
+			if err != nil {
+				return nil, fmt.Errorf("Error flattening `extension`: %+v", err)
+			}
+			if err := d.Set("extension", extensions); err != nil {
+				return nil, fmt.Errorf("Error setting `extension`: %+v", err)
+			}
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceArmVirtualMachineScaleSetDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).vmScaleSetClient
 	ctx := meta.(*ArmClient).StopContext
@@ -1069,6 +1192,9 @@ func resourceArmVirtualMachineScaleSetDelete(d *schema.ResourceData, meta interf
 	resGroup := id.ResourceGroup
 	name := id.Path["virtualMachineScaleSets"]
 
+	azureRMLockByName(name, virtualMachineScaleSetResourceName)
+	defer azureRMUnlockByName(name, virtualMachineScaleSetResourceName)
+
 	future, err := client.Delete(ctx, resGroup, name)
 	if err != nil {
 		return err
@@ -1456,6 +1582,41 @@ func flattenAzureRmVirtualMachineScaleSetStorageProfileImageReference(image *com
 	return []interface{}{result}
 }
 
+// resourceArmVirtualMachineScaleSetDeallocatedInstanceCount returns the number of deallocated instances in a Low Priority
+// Scale Set. The API doesn't expose why an instance was deallocated, so this includes both instances evicted with the
+// `Deallocate` policy and any deallocated by an operator - instances evicted with the `Delete` policy are removed instead
+func resourceArmVirtualMachineScaleSetDeallocatedInstanceCount(meta interface{}, resGroup string, name string, vmss compute.VirtualMachineScaleSet) (int, error) {
+	vmsClient := meta.(*ArmClient).vmScaleSetVMsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	props := vmss.VirtualMachineScaleSetProperties
+	if props == nil || props.VirtualMachineProfile == nil || props.VirtualMachineProfile.Priority != compute.Low {
+		return 0, nil
+	}
+
+	deallocated := 0
+	iterator, err := vmsClient.ListComplete(ctx, resGroup, name, "", "", "instanceView")
+	if err != nil {
+		return 0, fmt.Errorf("Error listing instances of Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+	for iterator.NotDone() {
+		instance := iterator.Value()
+		if instanceProps := instance.VirtualMachineScaleSetVMProperties; instanceProps != nil && instanceProps.InstanceView != nil && instanceProps.InstanceView.Statuses != nil {
+			for _, status := range *instanceProps.InstanceView.Statuses {
+				if status.Code != nil && strings.EqualFold(*status.Code, "PowerState/deallocated") {
+					deallocated++
+				}
+			}
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return 0, fmt.Errorf("Error listing instances of Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+		}
+	}
+
+	return deallocated, nil
+}
+
 func flattenAzureRmVirtualMachineScaleSetSku(sku *compute.Sku) []interface{} {
 	result := make(map[string]interface{})
 	result["name"] = *sku.Name
@@ -1510,6 +1671,252 @@ func flattenAzureRmVirtualMachineScaleSetExtensionProfile(profile *compute.Virtu
 	return result, nil
 }
 
+// resourceArmVirtualMachineScaleSetUpgradeInstances upgrades any instances which aren't running the latest model
+// in batches sized by the `rolling_upgrade_policy`, checking the health of the instances between each batch
+// resourceArmVirtualMachineScaleSetUpdateExtensions applies changes to the in-line extensions one at a time, so that
+// the extensions managed by the `azurerm_virtual_machine_scale_set_extension` resource are left as-is
+func resourceArmVirtualMachineScaleSetUpdateExtensions(d *schema.ResourceData, meta interface{}, resGroup string, name string) error {
+	client := meta.(*ArmClient).vmScaleSetExtensionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	oldRaw, newRaw := d.GetChange("extension")
+	oldExtensions := oldRaw.(*schema.Set)
+	newExtensions := newRaw.(*schema.Set)
+
+	newNames := make(map[string]struct{})
+	for _, raw := range newExtensions.List() {
+		newNames[raw.(map[string]interface{})["name"].(string)] = struct{}{}
+	}
+
+	for _, raw := range oldExtensions.Difference(newExtensions).List() {
+		extensionName := raw.(map[string]interface{})["name"].(string)
+		if _, ok := newNames[extensionName]; ok {
+			continue
+		}
+
+		log.Printf("[DEBUG] Removing Extension %q from Virtual Machine Scale Set %q (Resource Group %q)..", extensionName, name, resGroup)
+		future, err := client.Delete(ctx, resGroup, name, extensionName)
+		if err != nil {
+			if response.WasNotFound(future.Response()) {
+				continue
+			}
+			return fmt.Errorf("Error deleting Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", extensionName, name, resGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			if !response.WasNotFound(future.Response()) {
+				return fmt.Errorf("Error waiting for deletion of Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", extensionName, name, resGroup, err)
+			}
+		}
+	}
+
+	for _, raw := range newExtensions.Difference(oldExtensions).List() {
+		extension, err := expandAzureRMVirtualMachineScaleSetExtension(raw.(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+		extensionName := *extension.Name
+
+		log.Printf("[DEBUG] Creating/updating Extension %q on Virtual Machine Scale Set %q (Resource Group %q)..", extensionName, name, resGroup)
+		future, err := client.CreateOrUpdate(ctx, resGroup, name, extensionName, extension)
+		if err != nil {
+			return fmt.Errorf("Error creating/updating Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", extensionName, name, resGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for creation/update of Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", extensionName, name, resGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceArmVirtualMachineScaleSetUpgradeInstances(d *schema.ResourceData, meta interface{}, resGroup string, name string) error {
+	client := meta.(*ArmClient).vmScaleSetClient
+	vmsClient := meta.(*ArmClient).vmScaleSetVMsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	allInstanceIds := make([]string, 0)
+	outdatedInstanceIds := make([]string, 0)
+	instances, err := vmsClient.ListComplete(ctx, resGroup, name, "", "", "")
+	if err != nil {
+		return fmt.Errorf("Error listing instances: %+v", err)
+	}
+	for instances.NotDone() {
+		instance := instances.Value()
+		if instance.InstanceID != nil {
+			allInstanceIds = append(allInstanceIds, *instance.InstanceID)
+
+			if props := instance.VirtualMachineScaleSetVMProperties; props != nil && props.LatestModelApplied != nil && !*props.LatestModelApplied {
+				outdatedInstanceIds = append(outdatedInstanceIds, *instance.InstanceID)
+			}
+		}
+
+		if err := instances.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("Error listing instances: %+v", err)
+		}
+	}
+
+	if len(outdatedInstanceIds) == 0 {
+		log.Printf("[DEBUG] All instances of Virtual Machine Scale Set %q (Resource Group %q) are running the latest model", name, resGroup)
+		return nil
+	}
+
+	maxBatchInstancePercent := 20
+	maxUnhealthyInstancePercent := 20
+	maxUnhealthyUpgradedInstancePercent := 20
+	if v, ok := d.GetOk("rolling_upgrade_policy.0"); ok {
+		policy := v.(map[string]interface{})
+		maxBatchInstancePercent = policy["max_batch_instance_percent"].(int)
+		maxUnhealthyInstancePercent = policy["max_unhealthy_instance_percent"].(int)
+		maxUnhealthyUpgradedInstancePercent = policy["max_unhealthy_upgraded_instance_percent"].(int)
+	}
+
+	batchSize := len(allInstanceIds) * maxBatchInstancePercent / 100
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	upgradedInstanceIds := make([]string, 0)
+	for i := 0; i < len(outdatedInstanceIds); i += batchSize {
+		end := i + batchSize
+		if end > len(outdatedInstanceIds) {
+			end = len(outdatedInstanceIds)
+		}
+		batch := outdatedInstanceIds[i:end]
+
+		unhealthyInstances, err := resourceArmVirtualMachineScaleSetCountUnhealthyInstances(ctx, vmsClient, resGroup, name, allInstanceIds)
+		if err != nil {
+			return err
+		}
+		if unhealthyInstances*100 > len(allInstanceIds)*maxUnhealthyInstancePercent {
+			return fmt.Errorf("%d of %d instances are unhealthy, which exceeds the `max_unhealthy_instance_percent` of %d%%", unhealthyInstances, len(allInstanceIds), maxUnhealthyInstancePercent)
+		}
+
+		log.Printf("[DEBUG] Upgrading instances %q of Virtual Machine Scale Set %q (Resource Group %q)..", strings.Join(batch, ", "), name, resGroup)
+		instanceIds := compute.VirtualMachineScaleSetVMInstanceRequiredIDs{
+			InstanceIds: &batch,
+		}
+		future, err := client.UpdateInstances(ctx, resGroup, name, instanceIds)
+		if err != nil {
+			return fmt.Errorf("Error upgrading instances %q: %+v", strings.Join(batch, ", "), err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for upgrade of instances %q: %+v", strings.Join(batch, ", "), err)
+		}
+		upgradedInstanceIds = append(upgradedInstanceIds, batch...)
+
+		unhealthyUpgradedInstances, err := resourceArmVirtualMachineScaleSetCountUnhealthyInstances(ctx, vmsClient, resGroup, name, upgradedInstanceIds)
+		if err != nil {
+			return err
+		}
+		if unhealthyUpgradedInstances*100 > len(upgradedInstanceIds)*maxUnhealthyUpgradedInstancePercent {
+			return fmt.Errorf("%d of %d upgraded instances are unhealthy, which exceeds the `max_unhealthy_upgraded_instance_percent` of %d%%", unhealthyUpgradedInstances, len(upgradedInstanceIds), maxUnhealthyUpgradedInstancePercent)
+		}
+	}
+
+	return nil
+}
+
+// resourceArmVirtualMachineScaleSetCountUnhealthyInstances waits for all of the specified instances to report
+// a final health state (polling them together under a single timeout) and returns the number which are unhealthy
+func resourceArmVirtualMachineScaleSetCountUnhealthyInstances(ctx context.Context, client compute.VirtualMachineScaleSetVMsClient, resGroup string, name string, instanceIds []string) (int, error) {
+	unhealthy := 0
+	pendingInstanceIds := instanceIds
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"Pending"},
+		Target:     []string{"Done"},
+		Timeout:    30 * time.Minute,
+		MinTimeout: 15 * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			stillPending := make([]string, 0)
+			for _, instanceId := range pendingInstanceIds {
+				health, err := resourceArmVirtualMachineScaleSetInstanceHealth(ctx, client, resGroup, name, instanceId)
+				if err != nil {
+					return nil, "", err
+				}
+
+				switch health {
+				case "Unhealthy":
+					unhealthy++
+				case "Pending":
+					stillPending = append(stillPending, instanceId)
+				}
+			}
+			pendingInstanceIds = stillPending
+
+			if len(pendingInstanceIds) > 0 {
+				return pendingInstanceIds, "Pending", nil
+			}
+
+			return unhealthy, "Done", nil
+		},
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return 0, fmt.Errorf("Error waiting for the health of instances %q: %+v", strings.Join(pendingInstanceIds, ", "), err)
+	}
+
+	return unhealthy, nil
+}
+
+// resourceArmVirtualMachineScaleSetInstanceHealth returns whether the instance is `Healthy`, `Unhealthy` or still `Pending`
+func resourceArmVirtualMachineScaleSetInstanceHealth(ctx context.Context, client compute.VirtualMachineScaleSetVMsClient, resGroup string, name string, instanceId string) (string, error) {
+	view, err := client.GetInstanceView(ctx, resGroup, name, instanceId)
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving Instance View for instance %q: %+v", instanceId, err)
+	}
+
+	// when the Application Health extension is installed the health of the instance is reported directly
+	if health := view.VMHealth; health != nil && health.Status != nil && health.Status.Code != nil {
+		switch strings.ToLower(*health.Status.Code) {
+		case "healthstate/healthy":
+			return "Healthy", nil
+		case "healthstate/unhealthy":
+			return "Unhealthy", nil
+		default:
+			return "Pending", nil
+		}
+	}
+
+	// otherwise we fall back to the Provisioning & Power State of the instance
+	provisioningState := ""
+	powerState := ""
+	if statuses := view.Statuses; statuses != nil {
+		for _, status := range *statuses {
+			if status.Code == nil {
+				continue
+			}
+
+			code := strings.ToLower(*status.Code)
+			if strings.HasPrefix(code, "provisioningstate/") {
+				provisioningState = strings.TrimPrefix(code, "provisioningstate/")
+			}
+			if strings.HasPrefix(code, "powerstate/") {
+				powerState = strings.TrimPrefix(code, "powerstate/")
+			}
+		}
+	}
+
+	if provisioningState == "failed" {
+		return "Unhealthy", nil
+	}
+
+	if provisioningState == "succeeded" {
+		if powerState == "running" {
+			return "Healthy", nil
+		}
+
+		if powerState == "stopped" || powerState == "deallocated" {
+			return "Unhealthy", nil
+		}
+	}
+
+	return "Pending", nil
+}
+
 func resourceArmVirtualMachineScaleSetStorageProfileImageReferenceHash(v interface{}) int {
 	var buf bytes.Buffer
 
@@ -2165,60 +2572,68 @@ func expandAzureRMVirtualMachineScaleSetExtensions(d *schema.ResourceData) (*com
 	extensions := d.Get("extension").(*schema.Set).List()
 	resources := make([]compute.VirtualMachineScaleSetExtension, 0, len(extensions))
 	for _, e := range extensions {
-		config := e.(map[string]interface{})
-		name := config["name"].(string)
-		publisher := config["publisher"].(string)
-		t := config["type"].(string)
-		version := config["type_handler_version"].(string)
-
-		extension := compute.VirtualMachineScaleSetExtension{
-			Name: &name,
-			VirtualMachineScaleSetExtensionProperties: &compute.VirtualMachineScaleSetExtensionProperties{
-				Publisher:          &publisher,
-				Type:               &t,
-				TypeHandlerVersion: &version,
-			},
+		extension, err := expandAzureRMVirtualMachineScaleSetExtension(e.(map[string]interface{}))
+		if err != nil {
+			return nil, err
 		}
 
-		if u := config["auto_upgrade_minor_version"]; u != nil {
-			upgrade := u.(bool)
-			extension.VirtualMachineScaleSetExtensionProperties.AutoUpgradeMinorVersion = &upgrade
-		}
+		resources = append(resources, extension)
+	}
 
-		if a := config["provision_after_extensions"]; a != nil {
-			provision_after_extensions := config["provision_after_extensions"].(*schema.Set).List()
-			if len(provision_after_extensions) > 0 {
-				var provisionAfterExtensions []string
-				for _, a := range provision_after_extensions {
-					str := a.(string)
-					provisionAfterExtensions = append(provisionAfterExtensions, str)
-				}
-				extension.VirtualMachineScaleSetExtensionProperties.ProvisionAfterExtensions = &provisionAfterExtensions
-			}
-		}
+	return &compute.VirtualMachineScaleSetExtensionProfile{
+		Extensions: &resources,
+	}, nil
+}
 
-		if s := config["settings"].(string); s != "" {
-			settings, err := structure.ExpandJsonFromString(s)
-			if err != nil {
-				return nil, fmt.Errorf("unable to parse settings: %+v", err)
+func expandAzureRMVirtualMachineScaleSetExtension(config map[string]interface{}) (compute.VirtualMachineScaleSetExtension, error) {
+	name := config["name"].(string)
+	publisher := config["publisher"].(string)
+	t := config["type"].(string)
+	version := config["type_handler_version"].(string)
+
+	extension := compute.VirtualMachineScaleSetExtension{
+		Name: &name,
+		VirtualMachineScaleSetExtensionProperties: &compute.VirtualMachineScaleSetExtensionProperties{
+			Publisher:          &publisher,
+			Type:               &t,
+			TypeHandlerVersion: &version,
+		},
+	}
+
+	if u := config["auto_upgrade_minor_version"]; u != nil {
+		upgrade := u.(bool)
+		extension.VirtualMachineScaleSetExtensionProperties.AutoUpgradeMinorVersion = &upgrade
+	}
+
+	if a := config["provision_after_extensions"]; a != nil {
+		provision_after_extensions := config["provision_after_extensions"].(*schema.Set).List()
+		if len(provision_after_extensions) > 0 {
+			var provisionAfterExtensions []string
+			for _, a := range provision_after_extensions {
+				str := a.(string)
+				provisionAfterExtensions = append(provisionAfterExtensions, str)
 			}
-			extension.VirtualMachineScaleSetExtensionProperties.Settings = &settings
+			extension.VirtualMachineScaleSetExtensionProperties.ProvisionAfterExtensions = &provisionAfterExtensions
 		}
+	}
 
-		if s := config["protected_settings"].(string); s != "" {
-			protectedSettings, err := structure.ExpandJsonFromString(s)
-			if err != nil {
-				return nil, fmt.Errorf("unable to parse protected_settings: %+v", err)
-			}
-			extension.VirtualMachineScaleSetExtensionProperties.ProtectedSettings = &protectedSettings
+	if s := config["settings"].(string); s != "" {
+		settings, err := structure.ExpandJsonFromString(s)
+		if err != nil {
+			return extension, fmt.Errorf("unable to parse settings: %+v", err)
 		}
+		extension.VirtualMachineScaleSetExtensionProperties.Settings = &settings
+	}
 
-		resources = append(resources, extension)
+	if s := config["protected_settings"].(string); s != "" {
+		protectedSettings, err := structure.ExpandJsonFromString(s)
+		if err != nil {
+			return extension, fmt.Errorf("unable to parse protected_settings: %+v", err)
+		}
+		extension.VirtualMachineScaleSetExtensionProperties.ProtectedSettings = &protectedSettings
 	}
 
-	return &compute.VirtualMachineScaleSetExtensionProfile{
-		Extensions: &resources,
-	}, nil
+	return extension, nil
 }
 
 func expandAzureRmVirtualMachineScaleSetPlan(d *schema.ResourceData) (*compute.Plan, error) {
@@ -2255,10 +2670,22 @@ func azureRmVirtualMachineScaleSetSuppressRollingUpgradePolicyDiff(k, _, new str
 	return false
 }
 
+// azureRmVirtualMachineScaleSetUpgradesInstancesManually returns whether the instances are upgraded by us (rather
+// than by Azure) - in which case the `rolling_upgrade_policy` is only used to size the batches
+func azureRmVirtualMachineScaleSetUpgradesInstancesManually(d *schema.ResourceData) bool {
+	return d.Get("upgrade_instances_on_change").(bool) && strings.EqualFold(d.Get("upgrade_policy_mode").(string), string(compute.Manual))
+}
+
 // Make sure rolling_upgrade_policy is default value when upgrade_policy_mode is not Rolling.
+// The exception is Manual mode with upgrade_instances_on_change enabled, where the policy controls the batches.
 func azureRmVirtualMachineScaleSetCustomizeDiff(d *schema.ResourceDiff, _ interface{}) error {
 	mode := d.Get("upgrade_policy_mode").(string)
-	if strings.ToLower(mode) != "rolling" {
+	upgradeInstancesOnChange := d.Get("upgrade_instances_on_change").(bool)
+	if upgradeInstancesOnChange && strings.ToLower(mode) != "manual" {
+		return fmt.Errorf("`upgrade_instances_on_change` can only be enabled when `upgrade_policy_mode` is `Manual`")
+	}
+
+	if strings.ToLower(mode) != "rolling" && !upgradeInstancesOnChange {
 		if policyRaw, ok := d.GetOk("rolling_upgrade_policy.0"); ok {
 			policy := policyRaw.(map[string]interface{})
 			isDefault := (policy["max_batch_instance_percent"].(int) == 20) &&
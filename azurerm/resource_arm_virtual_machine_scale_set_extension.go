@@ -0,0 +1,282 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/structure"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmVirtualMachineScaleSetExtension() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmVirtualMachineScaleSetExtensionCreateUpdate,
+		Read:   resourceArmVirtualMachineScaleSetExtensionRead,
+		Update: resourceArmVirtualMachineScaleSetExtensionCreateUpdate,
+		Delete: resourceArmVirtualMachineScaleSetExtensionDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"virtual_machine_scale_set_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"publisher": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"type_handler_version": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"auto_upgrade_minor_version": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"force_update_tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"provision_after_extensions": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+			},
+
+			"settings": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.ValidateJsonString,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+
+			// due to the sensitive nature, these are not returned by the API
+			"protected_settings": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				ValidateFunc:     validation.ValidateJsonString,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+		},
+	}
+}
+
+func resourceArmVirtualMachineScaleSetExtensionCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmScaleSetExtensionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	virtualMachineScaleSetId, err := parseAzureResourceID(d.Get("virtual_machine_scale_set_id").(string))
+	if err != nil {
+		return fmt.Errorf("Error parsing Virtual Machine Scale Set ID %q: %+v", d.Get("virtual_machine_scale_set_id").(string), err)
+	}
+	resourceGroup := virtualMachineScaleSetId.ResourceGroup
+	vmScaleSetName := virtualMachineScaleSetId.Path["virtualMachineScaleSets"]
+
+	// extensions on the same Scale Set can't be modified concurrently
+	azureRMLockByName(vmScaleSetName, virtualMachineScaleSetResourceName)
+	defer azureRMUnlockByName(vmScaleSetName, virtualMachineScaleSetResourceName)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, vmScaleSetName, name, "")
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for existing Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", name, vmScaleSetName, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_virtual_machine_scale_set_extension", *existing.ID)
+		}
+	}
+
+	props := compute.VirtualMachineScaleSetExtensionProperties{
+		Publisher:                utils.String(d.Get("publisher").(string)),
+		Type:                     utils.String(d.Get("type").(string)),
+		TypeHandlerVersion:       utils.String(d.Get("type_handler_version").(string)),
+		AutoUpgradeMinorVersion:  utils.Bool(d.Get("auto_upgrade_minor_version").(bool)),
+		ProvisionAfterExtensions: utils.ExpandStringSlice(d.Get("provision_after_extensions").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("force_update_tag"); ok {
+		props.ForceUpdateTag = utils.String(v.(string))
+	}
+
+	if settingsString := d.Get("settings").(string); settingsString != "" {
+		settings, err := structure.ExpandJsonFromString(settingsString)
+		if err != nil {
+			return fmt.Errorf("Error parsing `settings`: %+v", err)
+		}
+		props.Settings = &settings
+	}
+
+	if protectedSettingsString := d.Get("protected_settings").(string); protectedSettingsString != "" {
+		protectedSettings, err := structure.ExpandJsonFromString(protectedSettingsString)
+		if err != nil {
+			return fmt.Errorf("Error parsing `protected_settings`: %+v", err)
+		}
+		props.ProtectedSettings = &protectedSettings
+	}
+
+	extension := compute.VirtualMachineScaleSetExtension{
+		Name: utils.String(name),
+		VirtualMachineScaleSetExtensionProperties: &props,
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, vmScaleSetName, name, extension)
+	if err != nil {
+		return fmt.Errorf("Error creating/updating Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", name, vmScaleSetName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for creation/update of Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", name, vmScaleSetName, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, vmScaleSetName, name, "")
+	if err != nil {
+		return fmt.Errorf("Error retrieving Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", name, vmScaleSetName, resourceGroup, err)
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("Error retrieving Extension %q (Virtual Machine Scale Set %q / Resource Group %q): `id` was nil", name, vmScaleSetName, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmVirtualMachineScaleSetExtensionRead(d, meta)
+}
+
+func resourceArmVirtualMachineScaleSetExtensionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmScaleSetExtensionsClient
+	vmScaleSetClient := meta.(*ArmClient).vmScaleSetClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	vmScaleSetName := id.Path["virtualMachineScaleSets"]
+	name := id.Path["extensions"]
+
+	vmScaleSet, err := vmScaleSetClient.Get(ctx, resourceGroup, vmScaleSetName)
+	if err != nil {
+		if utils.ResponseWasNotFound(vmScaleSet.Response) {
+			log.Printf("[DEBUG] Virtual Machine Scale Set %q was not found in Resource Group %q - removing Extension from state!", vmScaleSetName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Virtual Machine Scale Set %q (Resource Group %q): %+v", vmScaleSetName, resourceGroup, err)
+	}
+
+	resp, err := client.Get(ctx, resourceGroup, vmScaleSetName, name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Extension %q was not found on Virtual Machine Scale Set %q (Resource Group %q) - removing from state!", name, vmScaleSetName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", name, vmScaleSetName, resourceGroup, err)
+	}
+
+	d.Set("name", name)
+	d.Set("virtual_machine_scale_set_id", vmScaleSet.ID)
+
+	if props := resp.VirtualMachineScaleSetExtensionProperties; props != nil {
+		d.Set("auto_upgrade_minor_version", props.AutoUpgradeMinorVersion)
+		d.Set("force_update_tag", props.ForceUpdateTag)
+		d.Set("publisher", props.Publisher)
+		d.Set("type", props.Type)
+		d.Set("type_handler_version", props.TypeHandlerVersion)
+
+		if err := d.Set("provision_after_extensions", utils.FlattenStringSlice(props.ProvisionAfterExtensions)); err != nil {
+			return fmt.Errorf("Error setting `provision_after_extensions`: %+v", err)
+		}
+
+		settings := ""
+		if props.Settings != nil {
+			settingsVal, ok := props.Settings.(map[string]interface{})
+			if ok {
+				settingsJson, err := structure.FlattenJsonToString(settingsVal)
+				if err != nil {
+					return fmt.Errorf("Error flattening `settings`: %+v", err)
+				}
+				settings = settingsJson
+			}
+		}
+		d.Set("settings", settings)
+	}
+
+	return nil
+}
+
+func resourceArmVirtualMachineScaleSetExtensionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmScaleSetExtensionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	vmScaleSetName := id.Path["virtualMachineScaleSets"]
+	name := id.Path["extensions"]
+
+	azureRMLockByName(vmScaleSetName, virtualMachineScaleSetResourceName)
+	defer azureRMUnlockByName(vmScaleSetName, virtualMachineScaleSetResourceName)
+
+	future, err := client.Delete(ctx, resourceGroup, vmScaleSetName, name)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", name, vmScaleSetName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for deletion of Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", name, vmScaleSetName, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,276 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAccAzureRMVirtualMachineScaleSetExtension_basic(t *testing.T) {
+	resourceName := "azurerm_virtual_machine_scale_set_extension.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineScaleSetExtensionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMVirtualMachineScaleSetExtension_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExtensionExists(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMVirtualMachineScaleSetExtension_requiresImport(t *testing.T) {
+	if !requireResourcesToBeImported {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azurerm_virtual_machine_scale_set_extension.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineScaleSetExtensionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMVirtualMachineScaleSetExtension_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExtensionExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccAzureRMVirtualMachineScaleSetExtension_requiresImport(ri, location),
+				ExpectError: testRequiresImportError("azurerm_virtual_machine_scale_set_extension"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMVirtualMachineScaleSetExtension_update(t *testing.T) {
+	resourceName := "azurerm_virtual_machine_scale_set_extension.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineScaleSetExtensionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMVirtualMachineScaleSetExtension_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExtensionExists(resourceName),
+				),
+			},
+			{
+				Config: testAccAzureRMVirtualMachineScaleSetExtension_updated(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExtensionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "force_update_tag", "second"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"protected_settings",
+				},
+			},
+		},
+	})
+}
+
+func TestAccAzureRMVirtualMachineScaleSetExtension_provisionAfterExtensions(t *testing.T) {
+	resourceName := "azurerm_virtual_machine_scale_set_extension.second"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineScaleSetExtensionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMVirtualMachineScaleSetExtension_provisionAfterExtensions(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExtensionExists("azurerm_virtual_machine_scale_set_extension.test"),
+					testCheckAzureRMVirtualMachineScaleSetExtensionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "provision_after_extensions.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "provision_after_extensions.0", "CustomScript"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckAzureRMVirtualMachineScaleSetExtensionExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Virtual Machine Scale Set Extension not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		vmScaleSetId, err := parseAzureResourceID(rs.Primary.Attributes["virtual_machine_scale_set_id"])
+		if err != nil {
+			return err
+		}
+		resourceGroup := vmScaleSetId.ResourceGroup
+		vmScaleSetName := vmScaleSetId.Path["virtualMachineScaleSets"]
+
+		client := testAccProvider.Meta().(*ArmClient).vmScaleSetExtensionsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, vmScaleSetName, name, "")
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Extension %q (Virtual Machine Scale Set %q / Resource Group %q) does not exist", name, vmScaleSetName, resourceGroup)
+			}
+
+			return fmt.Errorf("Bad: Get on vmScaleSetExtensionsClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMVirtualMachineScaleSetExtensionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).vmScaleSetExtensionsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_virtual_machine_scale_set_extension" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		vmScaleSetId, err := parseAzureResourceID(rs.Primary.Attributes["virtual_machine_scale_set_id"])
+		if err != nil {
+			return err
+		}
+		resourceGroup := vmScaleSetId.ResourceGroup
+		vmScaleSetName := vmScaleSetId.Path["virtualMachineScaleSets"]
+
+		resp, err := client.Get(ctx, resourceGroup, vmScaleSetName, name, "")
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				continue
+			}
+
+			return fmt.Errorf("Bad: Get on vmScaleSetExtensionsClient: %+v", err)
+		}
+
+		return fmt.Errorf("Extension %q (Virtual Machine Scale Set %q / Resource Group %q) still exists", name, vmScaleSetName, resourceGroup)
+	}
+
+	return nil
+}
+
+func testAccAzureRMVirtualMachineScaleSetExtension_basic(rInt int, location string) string {
+	template := testAccAzureRMVirtualMachineScaleSet_basicLinux_managedDisk(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_virtual_machine_scale_set_extension" "test" {
+  name                         = "CustomScript"
+  virtual_machine_scale_set_id = "${azurerm_virtual_machine_scale_set.test.id}"
+  publisher                    = "Microsoft.Azure.Extensions"
+  type                         = "CustomScript"
+  type_handler_version         = "2.0"
+
+  settings = <<SETTINGS
+{
+  "commandToExecute": "echo $HOSTNAME"
+}
+SETTINGS
+}
+`, template)
+}
+
+func testAccAzureRMVirtualMachineScaleSetExtension_requiresImport(rInt int, location string) string {
+	template := testAccAzureRMVirtualMachineScaleSetExtension_basic(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_virtual_machine_scale_set_extension" "import" {
+  name                         = "${azurerm_virtual_machine_scale_set_extension.test.name}"
+  virtual_machine_scale_set_id = "${azurerm_virtual_machine_scale_set_extension.test.virtual_machine_scale_set_id}"
+  publisher                    = "${azurerm_virtual_machine_scale_set_extension.test.publisher}"
+  type                         = "${azurerm_virtual_machine_scale_set_extension.test.type}"
+  type_handler_version         = "${azurerm_virtual_machine_scale_set_extension.test.type_handler_version}"
+  settings                     = "${azurerm_virtual_machine_scale_set_extension.test.settings}"
+}
+`, template)
+}
+
+func testAccAzureRMVirtualMachineScaleSetExtension_updated(rInt int, location string) string {
+	template := testAccAzureRMVirtualMachineScaleSet_basicLinux_managedDisk(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_virtual_machine_scale_set_extension" "test" {
+  name                         = "CustomScript"
+  virtual_machine_scale_set_id = "${azurerm_virtual_machine_scale_set.test.id}"
+  publisher                    = "Microsoft.Azure.Extensions"
+  type                         = "CustomScript"
+  type_handler_version         = "2.0"
+  force_update_tag             = "second"
+
+  settings = <<SETTINGS
+{
+  "commandToExecute": "echo $HOSTNAME && hostname"
+}
+SETTINGS
+
+  protected_settings = <<SETTINGS
+{
+  "secret": "value"
+}
+SETTINGS
+}
+`, template)
+}
+
+func testAccAzureRMVirtualMachineScaleSetExtension_provisionAfterExtensions(rInt int, location string) string {
+	template := testAccAzureRMVirtualMachineScaleSetExtension_basic(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_virtual_machine_scale_set_extension" "second" {
+  name                         = "MSILinuxExtension"
+  virtual_machine_scale_set_id = "${azurerm_virtual_machine_scale_set.test.id}"
+  publisher                    = "Microsoft.ManagedIdentity"
+  type                         = "ManagedIdentityExtensionForLinux"
+  type_handler_version         = "1.0"
+  settings                     = "{\"port\": 50342}"
+
+  provision_after_extensions = [
+    "${azurerm_virtual_machine_scale_set_extension.test.name}",
+  ]
+}
+`, template)
+}
@@ -86,6 +86,7 @@ func TestAccAzureRMVirtualMachineScaleSet_evictionPolicyDelete(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMVirtualMachineScaleSetExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "eviction_policy", "Delete"),
+					resource.TestCheckResourceAttr(resourceName, "deallocated_instance_count", "0"),
 				),
 			},
 			{
@@ -343,6 +344,26 @@ func TestAccAzureRMVirtualMachineScaleSet_singlePlacementGroupFalse(t *testing.T
 	})
 }
 
+func TestAccAzureRMVirtualMachineScaleSet_proximityPlacementGroup(t *testing.T) {
+	resourceName := "azurerm_virtual_machine_scale_set.test"
+	ri := tf.AccRandTimeInt()
+	config := testAccAzureRMVirtualMachineScaleSet_proximityPlacementGroup(ri, testLocation())
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineScaleSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "proximity_placement_group_id"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAzureRMVirtualMachineScaleSet_linuxUpdated(t *testing.T) {
 	resourceName := "azurerm_virtual_machine_scale_set.test"
 	ri := tf.AccRandTimeInt()
@@ -399,6 +420,36 @@ func TestAccAzureRMVirtualMachineScaleSet_customDataUpdated(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMVirtualMachineScaleSet_upgradeInstancesOnChange(t *testing.T) {
+	resourceName := "azurerm_virtual_machine_scale_set.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineScaleSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMVirtualMachineScaleSet_upgradeInstancesOnChange(ri, location, "custom data!"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "upgrade_instances_on_change", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rolling_upgrade_policy.0.max_batch_instance_percent", "50"),
+				),
+			},
+			{
+				Config: testAccAzureRMVirtualMachineScaleSet_upgradeInstancesOnChange(ri, location, "updated custom data!"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExists(resourceName),
+					testCheckAzureRMVirtualMachineScaleSetInstancesUpToDate(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "rolling_upgrade_policy.0.max_batch_instance_percent", "50"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAzureRMVirtualMachineScaleSet_basicLinux_managedDisk(t *testing.T) {
 	resourceName := "azurerm_virtual_machine_scale_set.test"
 	ri := tf.AccRandTimeInt()
@@ -671,6 +722,36 @@ func TestAccAzureRMVirtualMachineScaleSet_priority(t *testing.T) {
 					testCheckAzureRMVirtualMachineScaleSetExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "priority", "Low"),
 					resource.TestCheckResourceAttr(resourceName, "eviction_policy", "Deallocate"),
+					resource.TestCheckResourceAttr(resourceName, "deallocated_instance_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMVirtualMachineScaleSet_priorityDeallocatedInstance(t *testing.T) {
+	resourceName := "azurerm_virtual_machine_scale_set.test"
+	ri := tf.AccRandTimeInt()
+	config := testAccAzureRMVirtualMachineScaleSetPriorityTemplate(ri, testLocation())
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineScaleSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "deallocated_instance_count", "0"),
+					testCheckAzureRMVirtualMachineScaleSetDeallocateInstance(resourceName),
+				),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "deallocated_instance_count", "1"),
+					resource.TestCheckResourceAttr(resourceName, "sku.0.capacity", "1"),
 				),
 			},
 		},
@@ -1085,6 +1166,43 @@ func testCheckAzureRMVirtualMachineScaleSetExists(name string) resource.TestChec
 	}
 }
 
+// testCheckAzureRMVirtualMachineScaleSetDeallocateInstance deallocates the first instance in the Scale Set,
+// which is how an instance evicted with the `Deallocate` policy is left
+func testCheckAzureRMVirtualMachineScaleSetDeallocateInstance(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		scaleSetName := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).vmScaleSetVMsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		instances, err := client.ListComplete(ctx, resourceGroup, scaleSetName, "", "", "")
+		if err != nil {
+			return fmt.Errorf("Bad: listing instances of Virtual Machine Scale Set %q: %+v", scaleSetName, err)
+		}
+		if !instances.NotDone() || instances.Value().InstanceID == nil {
+			return fmt.Errorf("Bad: Virtual Machine Scale Set %q has no instances", scaleSetName)
+		}
+		instanceId := *instances.Value().InstanceID
+
+		future, err := client.Deallocate(ctx, resourceGroup, scaleSetName, instanceId)
+		if err != nil {
+			return fmt.Errorf("Bad: deallocating instance %q of Virtual Machine Scale Set %q: %+v", instanceId, scaleSetName, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Bad: waiting for deallocation of instance %q of Virtual Machine Scale Set %q: %+v", instanceId, scaleSetName, err)
+		}
+
+		return nil
+	}
+}
+
 func testCheckAzureRMVirtualMachineScaleSetDisappears(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// Ensure we have enough information in state to look up in API
@@ -1141,6 +1259,39 @@ func testCheckAzureRMVirtualMachineScaleSetDestroy(s *terraform.State) error {
 	return nil
 }
 
+func testCheckAzureRMVirtualMachineScaleSetInstancesUpToDate(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		scaleSetName := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).vmScaleSetVMsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		instances, err := client.ListComplete(ctx, resourceGroup, scaleSetName, "", "", "")
+		if err != nil {
+			return fmt.Errorf("Bad: listing instances of Virtual Machine Scale Set %q: %+v", scaleSetName, err)
+		}
+
+		for instances.NotDone() {
+			instance := instances.Value()
+			if props := instance.VirtualMachineScaleSetVMProperties; props != nil && props.LatestModelApplied != nil && !*props.LatestModelApplied {
+				return fmt.Errorf("Bad: instance %q of Virtual Machine Scale Set %q is not running the latest model", *instance.InstanceID, scaleSetName)
+			}
+
+			if err := instances.NextWithContext(ctx); err != nil {
+				return fmt.Errorf("Bad: listing instances of Virtual Machine Scale Set %q: %+v", scaleSetName, err)
+			}
+		}
+
+		return nil
+	}
+}
+
 func testCheckAzureRMVirtualMachineScaleSetHasLoadbalancer(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		resp, err := testGetAzureRMVirtualMachineScaleSet(s, name)
@@ -2671,6 +2822,80 @@ resource "azurerm_virtual_machine_scale_set" "test" {
 `, rInt, location)
 }
 
+func testAccAzureRMVirtualMachineScaleSet_proximityPlacementGroup(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_proximity_placement_group" "test" {
+  name                = "acctestPPG-%[1]d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctvn-%[1]d"
+  address_space       = ["10.0.0.0/16"]
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "acctsub-%[1]d"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  virtual_network_name = "${azurerm_virtual_network.test.name}"
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurerm_virtual_machine_scale_set" "test" {
+  name                         = "acctvmss-%[1]d"
+  location                     = "${azurerm_resource_group.test.location}"
+  resource_group_name          = "${azurerm_resource_group.test.name}"
+  upgrade_policy_mode          = "Manual"
+  proximity_placement_group_id = "${azurerm_proximity_placement_group.test.id}"
+
+  sku {
+    name     = "Standard_D1_v2"
+    tier     = "Standard"
+    capacity = 2
+  }
+
+  os_profile {
+    computer_name_prefix = "testvm-%[1]d"
+    admin_username       = "myadmin"
+    admin_password       = "Passwword1234"
+  }
+
+  network_profile {
+    name    = "TestNetworkProfile-%[1]d"
+    primary = true
+
+    ip_configuration {
+      name      = "TestIPConfiguration"
+      primary   = true
+      subnet_id = "${azurerm_subnet.test.id}"
+    }
+  }
+
+  storage_profile_os_disk {
+    name              = ""
+    caching           = "ReadWrite"
+    create_option     = "FromImage"
+    managed_disk_type = "Standard_LRS"
+  }
+
+  storage_profile_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+`, rInt, location)
+}
+
 func testAccAzureRMVirtualMachineScaleSet_linux(rInt int, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
@@ -3093,6 +3318,79 @@ resource "azurerm_virtual_machine_scale_set" "test" {
 `, rInt, location)
 }
 
+func testAccAzureRMVirtualMachineScaleSet_upgradeInstancesOnChange(rInt int, location string, customData string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctvn-%[1]d"
+  address_space       = ["10.0.0.0/16"]
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "acctsub-%[1]d"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  virtual_network_name = "${azurerm_virtual_network.test.name}"
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurerm_virtual_machine_scale_set" "test" {
+  name                        = "acctvmss-%[1]d"
+  location                    = "${azurerm_resource_group.test.location}"
+  resource_group_name         = "${azurerm_resource_group.test.name}"
+  upgrade_policy_mode         = "Manual"
+  upgrade_instances_on_change = true
+
+  rolling_upgrade_policy {
+    max_batch_instance_percent = 50
+  }
+
+  sku {
+    name     = "Standard_D1_v2"
+    tier     = "Standard"
+    capacity = 2
+  }
+
+  os_profile {
+    computer_name_prefix = "testvm-%[1]d"
+    admin_username       = "myadmin"
+    admin_password       = "Passwword1234"
+    custom_data          = "%[3]s"
+  }
+
+  network_profile {
+    name    = "TestNetworkProfile-%[1]d"
+    primary = true
+
+    ip_configuration {
+      name      = "TestIPConfiguration"
+      primary   = true
+      subnet_id = "${azurerm_subnet.test.id}"
+    }
+  }
+
+  storage_profile_os_disk {
+    name              = ""
+    caching           = "ReadWrite"
+    create_option     = "FromImage"
+    managed_disk_type = "Standard_LRS"
+  }
+
+  storage_profile_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+`, rInt, location, customData)
+}
+
 func testAccAzureRMVirtualMachineScaleSet_basicLinux_managedDisk_withZones(rInt int, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
@@ -0,0 +1,417 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmVpnGateway() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmVpnGatewayCreateUpdate,
+		Read:   resourceArmVpnGatewayRead,
+		Update: resourceArmVpnGatewayCreateUpdate,
+		Delete: resourceArmVpnGatewayDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"location": azure.SchemaLocation(),
+
+			"virtual_hub_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"scale_unit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"bgp_settings": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"asn": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"peer_weight": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"bgp_peering_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"vpn_connection": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"remote_vpn_site_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"shared_key": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+
+						"protocol": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  string(network.IKEv2),
+							ValidateFunc: validation.StringInSlice([]string{
+								string(network.IKEv1),
+								string(network.IKEv2),
+							}, false),
+						},
+
+						"routing_weight": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+
+						"bandwidth_mbps": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"bgp_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"internet_security_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"rate_limiting_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceArmVpnGatewayCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vpnGatewayClient
+	ctx := meta.(*ArmClient).StopContext
+
+	log.Printf("[INFO] preparing arguments for VPN Gateway creation.")
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing VPN Gateway %q (Resource Group %q): %s", name, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_vpn_gateway", *existing.ID)
+		}
+	}
+
+	location := azure.NormalizeLocation(d.Get("location").(string))
+	virtualHubId := d.Get("virtual_hub_id").(string)
+	scaleUnit := d.Get("scale_unit").(int)
+	tags := d.Get("tags").(map[string]interface{})
+
+	gateway := network.VpnGateway{
+		Location: utils.String(location),
+		Tags:     expandTags(tags),
+		VpnGatewayProperties: &network.VpnGatewayProperties{
+			VirtualHub: &network.SubResource{
+				ID: utils.String(virtualHubId),
+			},
+			VpnGatewayScaleUnit: utils.Int32(int32(scaleUnit)),
+			BgpSettings:         expandArmVpnGatewayBgpSettings(d.Get("bgp_settings").([]interface{})),
+			Connections:         expandArmVpnGatewayConnections(d.Get("vpn_connection").([]interface{})),
+		},
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, name, gateway)
+	if err != nil {
+		return fmt.Errorf("Error creating/updating VPN Gateway %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for the creation/update of VPN Gateway %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving VPN Gateway %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read VPN Gateway %q (Resource Group %q) ID", name, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmVpnGatewayRead(d, meta)
+}
+
+func resourceArmVpnGatewayRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vpnGatewayClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["vpnGateways"]
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] VPN Gateway %q was not found in Resource Group %q - removing from state", name, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving VPN Gateway %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+	if location := resp.Location; location != nil {
+		d.Set("location", azure.NormalizeLocation(*location))
+	}
+
+	if props := resp.VpnGatewayProperties; props != nil {
+		virtualHubId := ""
+		if props.VirtualHub != nil && props.VirtualHub.ID != nil {
+			virtualHubId = *props.VirtualHub.ID
+		}
+		d.Set("virtual_hub_id", virtualHubId)
+
+		scaleUnit := 0
+		if props.VpnGatewayScaleUnit != nil {
+			scaleUnit = int(*props.VpnGatewayScaleUnit)
+		}
+		d.Set("scale_unit", scaleUnit)
+
+		if err := d.Set("bgp_settings", flattenArmVpnGatewayBgpSettings(props.BgpSettings)); err != nil {
+			return fmt.Errorf("Error setting `bgp_settings`: %+v", err)
+		}
+
+		// the API doesn't return the shared key, so we pull it out of the existing state
+		if err := d.Set("vpn_connection", flattenArmVpnGatewayConnections(d, props.Connections)); err != nil {
+			return fmt.Errorf("Error setting `vpn_connection`: %+v", err)
+		}
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmVpnGatewayDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vpnGatewayClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["vpnGateways"]
+
+	future, err := client.Delete(ctx, resourceGroup, name)
+	if err != nil {
+		// deleted outside of Terraform
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting VPN Gateway %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for the deletion of VPN Gateway %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func expandArmVpnGatewayBgpSettings(input []interface{}) *network.BgpSettings {
+	if len(input) == 0 {
+		return nil
+	}
+
+	setting := input[0].(map[string]interface{})
+
+	return &network.BgpSettings{
+		Asn:        utils.Int64(int64(setting["asn"].(int))),
+		PeerWeight: utils.Int32(int32(setting["peer_weight"].(int))),
+	}
+}
+
+func expandArmVpnGatewayConnections(input []interface{}) *[]network.VpnConnection {
+	connections := make([]network.VpnConnection, 0)
+
+	for _, v := range input {
+		connection := v.(map[string]interface{})
+
+		props := network.VpnConnectionProperties{
+			RemoteVpnSite: &network.SubResource{
+				ID: utils.String(connection["remote_vpn_site_id"].(string)),
+			},
+			VpnConnectionProtocolType: network.VirtualNetworkGatewayConnectionProtocol(connection["protocol"].(string)),
+			RoutingWeight:             utils.Int32(int32(connection["routing_weight"].(int))),
+			ConnectionBandwidth:       utils.Int32(int32(connection["bandwidth_mbps"].(int))),
+			EnableBgp:                 utils.Bool(connection["bgp_enabled"].(bool)),
+			EnableInternetSecurity:    utils.Bool(connection["internet_security_enabled"].(bool)),
+			EnableRateLimiting:        utils.Bool(connection["rate_limiting_enabled"].(bool)),
+		}
+
+		if sharedKey := connection["shared_key"].(string); sharedKey != "" {
+			props.SharedKey = utils.String(sharedKey)
+		}
+
+		connections = append(connections, network.VpnConnection{
+			Name:                    utils.String(connection["name"].(string)),
+			VpnConnectionProperties: &props,
+		})
+	}
+
+	return &connections
+}
+
+func flattenArmVpnGatewayBgpSettings(input *network.BgpSettings) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make(map[string]interface{})
+
+	if input.Asn != nil {
+		output["asn"] = int(*input.Asn)
+	}
+
+	if input.PeerWeight != nil {
+		output["peer_weight"] = int(*input.PeerWeight)
+	}
+
+	if input.BgpPeeringAddress != nil {
+		output["bgp_peering_address"] = *input.BgpPeeringAddress
+	}
+
+	return []interface{}{output}
+}
+
+func flattenArmVpnGatewayConnections(d *schema.ResourceData, input *[]network.VpnConnection) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	existingSharedKeys := make(map[string]string)
+	for _, v := range d.Get("vpn_connection").([]interface{}) {
+		existing := v.(map[string]interface{})
+		existingSharedKeys[existing["name"].(string)] = existing["shared_key"].(string)
+	}
+
+	for _, connection := range *input {
+		result := make(map[string]interface{})
+
+		if connection.Name != nil {
+			result["name"] = *connection.Name
+			result["shared_key"] = existingSharedKeys[*connection.Name]
+		}
+
+		if props := connection.VpnConnectionProperties; props != nil {
+			if props.RemoteVpnSite != nil && props.RemoteVpnSite.ID != nil {
+				result["remote_vpn_site_id"] = *props.RemoteVpnSite.ID
+			}
+
+			result["protocol"] = string(props.VpnConnectionProtocolType)
+
+			if props.RoutingWeight != nil {
+				result["routing_weight"] = int(*props.RoutingWeight)
+			}
+
+			if props.ConnectionBandwidth != nil {
+				result["bandwidth_mbps"] = int(*props.ConnectionBandwidth)
+			}
+
+			if props.EnableBgp != nil {
+				result["bgp_enabled"] = *props.EnableBgp
+			}
+
+			if props.EnableInternetSecurity != nil {
+				result["internet_security_enabled"] = *props.EnableInternetSecurity
+			}
+
+			if props.EnableRateLimiting != nil {
+				result["rate_limiting_enabled"] = *props.EnableRateLimiting
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -0,0 +1,315 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmVpnSite() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmVpnSiteCreateUpdate,
+		Read:   resourceArmVpnSiteRead,
+		Update: resourceArmVpnSiteCreateUpdate,
+		Delete: resourceArmVpnSiteDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"location": azure.SchemaLocation(),
+
+			"virtual_wan_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"ip_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.IPv4Address,
+			},
+
+			"address_space": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.CIDR,
+				},
+			},
+
+			"device_vendor": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"device_model": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"link_speed_in_mbps": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"bgp_settings": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"asn": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"bgp_peering_address": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.IPv4Address,
+						},
+
+						"peer_weight": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"security_site_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceArmVpnSiteCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vpnSitesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	log.Printf("[INFO] preparing arguments for VPN Site creation.")
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing VPN Site %q (Resource Group %q): %s", name, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_vpn_site", *existing.ID)
+		}
+	}
+
+	location := azure.NormalizeLocation(d.Get("location").(string))
+	virtualWanId := d.Get("virtual_wan_id").(string)
+	ipAddress := d.Get("ip_address").(string)
+	tags := d.Get("tags").(map[string]interface{})
+
+	addressPrefixes := make([]string, 0)
+	for _, prefix := range d.Get("address_space").([]interface{}) {
+		addressPrefixes = append(addressPrefixes, prefix.(string))
+	}
+
+	site := network.VpnSite{
+		Location: utils.String(location),
+		Tags:     expandTags(tags),
+		VpnSiteProperties: &network.VpnSiteProperties{
+			VirtualWan: &network.SubResource{
+				ID: utils.String(virtualWanId),
+			},
+			IPAddress: utils.String(ipAddress),
+			AddressSpace: &network.AddressSpace{
+				AddressPrefixes: &addressPrefixes,
+			},
+			DeviceProperties: &network.DeviceProperties{
+				DeviceVendor:    utils.String(d.Get("device_vendor").(string)),
+				DeviceModel:     utils.String(d.Get("device_model").(string)),
+				LinkSpeedInMbps: utils.Int32(int32(d.Get("link_speed_in_mbps").(int))),
+			},
+			BgpProperties:  expandArmVpnSiteBgpSettings(d.Get("bgp_settings").([]interface{})),
+			IsSecuritySite: utils.Bool(d.Get("security_site_enabled").(bool)),
+		},
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, name, site)
+	if err != nil {
+		return fmt.Errorf("Error creating/updating VPN Site %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for the creation/update of VPN Site %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving VPN Site %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read VPN Site %q (Resource Group %q) ID", name, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmVpnSiteRead(d, meta)
+}
+
+func resourceArmVpnSiteRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vpnSitesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["vpnSites"]
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] VPN Site %q was not found in Resource Group %q - removing from state", name, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving VPN Site %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+	if location := resp.Location; location != nil {
+		d.Set("location", azure.NormalizeLocation(*location))
+	}
+
+	if props := resp.VpnSiteProperties; props != nil {
+		virtualWanId := ""
+		if props.VirtualWan != nil && props.VirtualWan.ID != nil {
+			virtualWanId = *props.VirtualWan.ID
+		}
+		d.Set("virtual_wan_id", virtualWanId)
+		d.Set("ip_address", props.IPAddress)
+		d.Set("security_site_enabled", props.IsSecuritySite)
+
+		addressPrefixes := make([]string, 0)
+		if space := props.AddressSpace; space != nil && space.AddressPrefixes != nil {
+			addressPrefixes = *space.AddressPrefixes
+		}
+		if err := d.Set("address_space", addressPrefixes); err != nil {
+			return fmt.Errorf("Error setting `address_space`: %+v", err)
+		}
+
+		if device := props.DeviceProperties; device != nil {
+			d.Set("device_vendor", device.DeviceVendor)
+			d.Set("device_model", device.DeviceModel)
+			d.Set("link_speed_in_mbps", device.LinkSpeedInMbps)
+		}
+
+		if err := d.Set("bgp_settings", flattenArmVpnSiteBgpSettings(props.BgpProperties)); err != nil {
+			return fmt.Errorf("Error setting `bgp_settings`: %+v", err)
+		}
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmVpnSiteDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vpnSitesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["vpnSites"]
+
+	future, err := client.Delete(ctx, resourceGroup, name)
+	if err != nil {
+		// deleted outside of Terraform
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting VPN Site %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for the deletion of VPN Site %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func expandArmVpnSiteBgpSettings(input []interface{}) *network.BgpSettings {
+	if len(input) == 0 {
+		return nil
+	}
+
+	setting := input[0].(map[string]interface{})
+
+	return &network.BgpSettings{
+		Asn:               utils.Int64(int64(setting["asn"].(int))),
+		BgpPeeringAddress: utils.String(setting["bgp_peering_address"].(string)),
+		PeerWeight:        utils.Int32(int32(setting["peer_weight"].(int))),
+	}
+}
+
+func flattenArmVpnSiteBgpSettings(input *network.BgpSettings) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make(map[string]interface{})
+
+	if input.Asn != nil {
+		output["asn"] = int(*input.Asn)
+	}
+
+	if input.BgpPeeringAddress != nil {
+		output["bgp_peering_address"] = *input.BgpPeeringAddress
+	}
+
+	if input.PeerWeight != nil {
+		output["peer_weight"] = int(*input.PeerWeight)
+	}
+
+	return []interface{}{output}
+}
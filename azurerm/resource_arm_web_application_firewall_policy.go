@@ -0,0 +1,493 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmWebApplicationFirewallPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmWebApplicationFirewallPolicyCreateUpdate,
+		Read:   resourceArmWebApplicationFirewallPolicyRead,
+		Update: resourceArmWebApplicationFirewallPolicyCreateUpdate,
+		Delete: resourceArmWebApplicationFirewallPolicyDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"location": azure.SchemaLocation(),
+
+			"custom_rules": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(network.WebApplicationFirewallActionAllow),
+								string(network.WebApplicationFirewallActionBlock),
+								string(network.WebApplicationFirewallActionLog),
+							}, false),
+						},
+
+						"match_conditions": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"match_values": {
+										Type:     schema.TypeList,
+										Required: true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validate.NoEmptyStrings,
+										},
+									},
+
+									"match_variables": {
+										Type:     schema.TypeList,
+										Required: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"variable_name": {
+													Type:     schema.TypeString,
+													Required: true,
+													ValidateFunc: validation.StringInSlice([]string{
+														string(network.PostArgs),
+														string(network.QueryString),
+														string(network.RemoteAddr),
+														string(network.RequestBody),
+														string(network.RequestCookies),
+														string(network.RequestHeaders),
+														string(network.RequestMethod),
+														string(network.RequestURI),
+													}, false),
+												},
+
+												"selector": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ValidateFunc: validate.NoEmptyStrings,
+												},
+											},
+										},
+									},
+
+									"operator": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(network.WebApplicationFirewallOperatorBeginsWith),
+											string(network.WebApplicationFirewallOperatorContains),
+											string(network.WebApplicationFirewallOperatorEndsWith),
+											string(network.WebApplicationFirewallOperatorEqual),
+											string(network.WebApplicationFirewallOperatorGreaterThan),
+											string(network.WebApplicationFirewallOperatorGreaterThanOrEqual),
+											string(network.WebApplicationFirewallOperatorIPMatch),
+											string(network.WebApplicationFirewallOperatorLessThan),
+											string(network.WebApplicationFirewallOperatorLessThanOrEqual),
+											string(network.WebApplicationFirewallOperatorRegex),
+										}, false),
+									},
+
+									"negation_condition": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+
+									"transforms": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{
+												string(network.HTMLEntityDecode),
+												string(network.Lowercase),
+												string(network.RemoveNulls),
+												string(network.Trim),
+												string(network.URLDecode),
+												string(network.URLEncode),
+											}, false),
+										},
+									},
+								},
+							},
+						},
+
+						"priority": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"rule_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(network.WebApplicationFirewallRuleTypeMatchRule),
+							}, false),
+						},
+
+						"name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+					},
+				},
+			},
+
+			"policy_settings": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(network.WebApplicationFirewallModePrevention),
+								string(network.WebApplicationFirewallModeDetection),
+							}, false),
+							Default: string(network.WebApplicationFirewallModePrevention),
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceArmWebApplicationFirewallPolicyCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).webApplicationFirewallPolicyClient
+	ctx := meta.(*ArmClient).StopContext
+
+	log.Printf("[INFO] preparing arguments for Web Application Firewall Policy creation.")
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing Web Application Firewall Policy %q (Resource Group %q): %s", name, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_web_application_firewall_policy", *existing.ID)
+		}
+	}
+
+	location := azure.NormalizeLocation(d.Get("location").(string))
+	customRules := d.Get("custom_rules").([]interface{})
+	policySettings := d.Get("policy_settings").([]interface{})
+	tags := d.Get("tags").(map[string]interface{})
+
+	parameters := network.WebApplicationFirewallPolicy{
+		Location: utils.String(location),
+		WebApplicationFirewallPolicyPropertiesFormat: &network.WebApplicationFirewallPolicyPropertiesFormat{
+			CustomRules:    expandArmWebApplicationFirewallPolicyWebApplicationFirewallCustomRule(customRules),
+			PolicySettings: expandArmWebApplicationFirewallPolicyPolicySettings(policySettings),
+		},
+		Tags: expandTags(tags),
+	}
+
+	if _, err := client.CreateOrUpdate(ctx, resourceGroup, name, parameters); err != nil {
+		return fmt.Errorf("Error creating/updating Web Application Firewall Policy %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Web Application Firewall Policy %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	if resp.ID == nil {
+		return fmt.Errorf("Cannot read Web Application Firewall Policy %q (Resource Group %q) ID", name, resourceGroup)
+	}
+
+	d.SetId(*resp.ID)
+
+	return resourceArmWebApplicationFirewallPolicyRead(d, meta)
+}
+
+func resourceArmWebApplicationFirewallPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).webApplicationFirewallPolicyClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["ApplicationGatewayWebApplicationFirewallPolicies"]
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Web Application Firewall Policy %q was not found in Resource Group %q - removing from state", name, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Web Application Firewall Policy %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+	if location := resp.Location; location != nil {
+		d.Set("location", azure.NormalizeLocation(*location))
+	}
+
+	if props := resp.WebApplicationFirewallPolicyPropertiesFormat; props != nil {
+		if err := d.Set("custom_rules", flattenArmWebApplicationFirewallPolicyWebApplicationFirewallCustomRule(props.CustomRules)); err != nil {
+			return fmt.Errorf("Error setting `custom_rules`: %+v", err)
+		}
+		if err := d.Set("policy_settings", flattenArmWebApplicationFirewallPolicyPolicySettings(props.PolicySettings)); err != nil {
+			return fmt.Errorf("Error setting `policy_settings`: %+v", err)
+		}
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmWebApplicationFirewallPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).webApplicationFirewallPolicyClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["ApplicationGatewayWebApplicationFirewallPolicies"]
+
+	future, err := client.Delete(ctx, resourceGroup, name)
+	if err != nil {
+		// deleted outside of Terraform
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting Web Application Firewall Policy %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for the deletion of Web Application Firewall Policy %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func expandArmWebApplicationFirewallPolicyWebApplicationFirewallCustomRule(input []interface{}) *[]network.WebApplicationFirewallCustomRule {
+	results := make([]network.WebApplicationFirewallCustomRule, 0)
+	for _, item := range input {
+		v := item.(map[string]interface{})
+		name := v["name"].(string)
+		priority := v["priority"].(int)
+		ruleType := v["rule_type"].(string)
+		matchConditions := v["match_conditions"].([]interface{})
+		action := v["action"].(string)
+
+		result := network.WebApplicationFirewallCustomRule{
+			Action:          network.WebApplicationFirewallAction(action),
+			MatchConditions: expandArmWebApplicationFirewallPolicyMatchCondition(matchConditions),
+			Priority:        utils.Int32(int32(priority)),
+			RuleType:        network.WebApplicationFirewallRuleType(ruleType),
+		}
+
+		if name != "" {
+			result.Name = utils.String(name)
+		}
+
+		results = append(results, result)
+	}
+	return &results
+}
+
+func expandArmWebApplicationFirewallPolicyPolicySettings(input []interface{}) *network.PolicySettings {
+	if len(input) == 0 {
+		return nil
+	}
+	v := input[0].(map[string]interface{})
+
+	enabled := network.WebApplicationFirewallEnabledStateDisabled
+	if v["enabled"].(bool) {
+		enabled = network.WebApplicationFirewallEnabledStateEnabled
+	}
+
+	return &network.PolicySettings{
+		EnabledState: enabled,
+		Mode:         network.WebApplicationFirewallMode(v["mode"].(string)),
+	}
+}
+
+func expandArmWebApplicationFirewallPolicyMatchCondition(input []interface{}) *[]network.MatchCondition {
+	results := make([]network.MatchCondition, 0)
+	for _, item := range input {
+		v := item.(map[string]interface{})
+		matchVariables := v["match_variables"].([]interface{})
+		operator := v["operator"].(string)
+		negationCondition := v["negation_condition"].(bool)
+		matchValues := v["match_values"].([]interface{})
+
+		transforms := make([]network.WebApplicationFirewallTransform, 0)
+		for _, transform := range v["transforms"].(*schema.Set).List() {
+			transforms = append(transforms, network.WebApplicationFirewallTransform(transform.(string)))
+		}
+
+		result := network.MatchCondition{
+			MatchValues:      utils.ExpandStringSlice(matchValues),
+			MatchVariables:   expandArmWebApplicationFirewallPolicyMatchVariable(matchVariables),
+			NegationConditon: utils.Bool(negationCondition),
+			Operator:         network.WebApplicationFirewallOperator(operator),
+			Transforms:       &transforms,
+		}
+
+		results = append(results, result)
+	}
+	return &results
+}
+
+func expandArmWebApplicationFirewallPolicyMatchVariable(input []interface{}) *[]network.MatchVariable {
+	results := make([]network.MatchVariable, 0)
+	for _, item := range input {
+		v := item.(map[string]interface{})
+		variableName := v["variable_name"].(string)
+		selector := v["selector"].(string)
+
+		result := network.MatchVariable{
+			VariableName: network.WebApplicationFirewallMatchVariable(variableName),
+		}
+
+		if selector != "" {
+			result.Selector = utils.String(selector)
+		}
+
+		results = append(results, result)
+	}
+	return &results
+}
+
+func flattenArmWebApplicationFirewallPolicyWebApplicationFirewallCustomRule(input *[]network.WebApplicationFirewallCustomRule) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		v := make(map[string]interface{})
+
+		if name := item.Name; name != nil {
+			v["name"] = *name
+		}
+		if priority := item.Priority; priority != nil {
+			v["priority"] = int(*priority)
+		}
+		v["rule_type"] = string(item.RuleType)
+		v["match_conditions"] = flattenArmWebApplicationFirewallPolicyMatchCondition(item.MatchConditions)
+		v["action"] = string(item.Action)
+
+		results = append(results, v)
+	}
+
+	return results
+}
+
+func flattenArmWebApplicationFirewallPolicyPolicySettings(input *network.PolicySettings) []interface{} {
+	if input == nil {
+		return make([]interface{}, 0)
+	}
+
+	result := make(map[string]interface{})
+	result["enabled"] = input.EnabledState == network.WebApplicationFirewallEnabledStateEnabled
+	result["mode"] = string(input.Mode)
+
+	return []interface{}{result}
+}
+
+func flattenArmWebApplicationFirewallPolicyMatchCondition(input *[]network.MatchCondition) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		v := make(map[string]interface{})
+
+		if negationCondition := item.NegationConditon; negationCondition != nil {
+			v["negation_condition"] = *negationCondition
+		}
+
+		transforms := make([]interface{}, 0)
+		if item.Transforms != nil {
+			for _, transform := range *item.Transforms {
+				transforms = append(transforms, string(transform))
+			}
+		}
+
+		v["match_values"] = utils.FlattenStringSlice(item.MatchValues)
+		v["match_variables"] = flattenArmWebApplicationFirewallPolicyMatchVariable(item.MatchVariables)
+		v["operator"] = string(item.Operator)
+		v["transforms"] = schema.NewSet(schema.HashString, transforms)
+
+		results = append(results, v)
+	}
+
+	return results
+}
+
+func flattenArmWebApplicationFirewallPolicyMatchVariable(input *[]network.MatchVariable) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		v := make(map[string]interface{})
+
+		if selector := item.Selector; selector != nil {
+			v["selector"] = *selector
+		}
+		v["variable_name"] = string(item.VariableName)
+
+		results = append(results, v)
+	}
+
+	return results
+}
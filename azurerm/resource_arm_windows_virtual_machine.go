@@ -0,0 +1,706 @@
+package azurerm
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmWindowsVirtualMachine() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmWindowsVirtualMachineCreate,
+		Read:   resourceArmWindowsVirtualMachineRead,
+		Update: resourceArmWindowsVirtualMachineUpdate,
+		Delete: resourceArmWindowsVirtualMachineDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"location": azure.SchemaLocation(),
+
+			"admin_username": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"admin_password": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"network_interface_ids": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: azure.ValidateResourceID,
+				},
+			},
+
+			"os_disk": virtualMachineOSDiskSchema(),
+
+			"size": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"allow_extension_operations": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+
+			"availability_set_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+				// the Compute/VM API is broken and returns the Availability Set name in UPPERCASE :shrug:
+				DiffSuppressFunc: suppress.CaseDifference,
+				ConflictsWith:    []string{"zone"},
+			},
+
+			"boot_diagnostics": virtualMachineBootDiagnosticsSchema(),
+
+			"computer_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+				// Windows computer names are limited to 15 characters
+				ValidateFunc: validation.StringLenBetween(1, 15),
+			},
+
+			"custom_data": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				ValidateFunc: validate.Base64String(),
+			},
+
+			"enable_automatic_updates": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+
+			"identity": virtualMachineIdentitySchema(),
+
+			"license_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"Windows_Client",
+					"Windows_Server",
+				}, false),
+			},
+
+			"provision_vm_agent": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+
+			"secret": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_vault_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"certificate": {
+							Type:     schema.TypeSet,
+							Required: true,
+							ForceNew: true,
+							MinItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"store": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validate.NoEmptyStrings,
+									},
+
+									"url": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: azure.ValidateKeyVaultChildId,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"source_image_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"source_image_reference": virtualMachineSourceImageReferenceSchema(),
+
+			"timezone": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppress.CaseDifference,
+				ValidateFunc:     validateAzureVirtualMachineTimeZone(),
+			},
+
+			"winrm_listener": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Set:      resourceArmWindowsVirtualMachineWinRMListenerHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protocol": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(compute.HTTP),
+								string(compute.HTTPS),
+							}, false),
+						},
+
+						"certificate_url": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: azure.ValidateKeyVaultChildId,
+						},
+					},
+				},
+			},
+
+			"zone": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validate.NoEmptyStrings,
+				ConflictsWith: []string{"availability_set_id"},
+			},
+
+			"tags": tagsSchema(),
+
+			"private_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"virtual_machine_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmWindowsVirtualMachineCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	azureRMLockByName(name, virtualMachineResourceName)
+	defer azureRMUnlockByName(name, virtualMachineResourceName)
+
+	if requireResourcesToBeImported {
+		existing, err := client.Get(ctx, resourceGroup, name, "")
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for existing Windows Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_windows_virtual_machine", *existing.ID)
+		}
+	}
+
+	location := azure.NormalizeLocation(d.Get("location").(string))
+	tags := d.Get("tags").(map[string]interface{})
+
+	computerName := d.Get("computer_name").(string)
+	if computerName == "" {
+		if len(name) > 15 {
+			return fmt.Errorf("`computer_name` must be specified when `name` is longer than 15 characters, since Windows computer names are limited to 15 characters")
+		}
+
+		computerName = name
+	}
+
+	identity, err := expandVirtualMachineIdentity(d.Get("identity").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("Error expanding `identity`: %+v", err)
+	}
+
+	sourceImageReference, err := expandVirtualMachineSourceImageReference(d)
+	if err != nil {
+		return err
+	}
+
+	winRmListeners, err := expandWindowsVirtualMachineWinRMListeners(d.Get("winrm_listener").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	networkInterfaceIds := expandVirtualMachineNetworkInterfaceIDs(d.Get("network_interface_ids").([]interface{}))
+	secrets := expandWindowsVirtualMachineSecrets(d.Get("secret").([]interface{}))
+
+	params := compute.VirtualMachine{
+		Name:     utils.String(name),
+		Location: utils.String(location),
+		Identity: identity,
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			HardwareProfile: &compute.HardwareProfile{
+				VMSize: compute.VirtualMachineSizeTypes(d.Get("size").(string)),
+			},
+			OsProfile: &compute.OSProfile{
+				AdminUsername:            utils.String(d.Get("admin_username").(string)),
+				AdminPassword:            utils.String(d.Get("admin_password").(string)),
+				ComputerName:             utils.String(computerName),
+				AllowExtensionOperations: utils.Bool(d.Get("allow_extension_operations").(bool)),
+				Secrets:                  &secrets,
+				WindowsConfiguration: &compute.WindowsConfiguration{
+					EnableAutomaticUpdates: utils.Bool(d.Get("enable_automatic_updates").(bool)),
+					ProvisionVMAgent:       utils.Bool(d.Get("provision_vm_agent").(bool)),
+					WinRM: &compute.WinRMConfiguration{
+						Listeners: &winRmListeners,
+					},
+				},
+			},
+			NetworkProfile: &compute.NetworkProfile{
+				NetworkInterfaces: &networkInterfaceIds,
+			},
+			StorageProfile: &compute.StorageProfile{
+				ImageReference: sourceImageReference,
+				OsDisk:         expandVirtualMachineOSDisk(d.Get("os_disk").([]interface{}), compute.Windows),
+			},
+			DiagnosticsProfile: expandVirtualMachineBootDiagnostics(d.Get("boot_diagnostics").([]interface{})),
+		},
+		Tags: expandTags(tags),
+	}
+
+	if v, ok := d.GetOk("availability_set_id"); ok {
+		params.AvailabilitySet = &compute.SubResource{
+			ID: utils.String(v.(string)),
+		}
+	}
+
+	if v, ok := d.GetOk("custom_data"); ok {
+		params.OsProfile.CustomData = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("license_type"); ok {
+		params.LicenseType = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("timezone"); ok {
+		params.OsProfile.WindowsConfiguration.TimeZone = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("zone"); ok {
+		params.Zones = &[]string{
+			v.(string),
+		}
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, name, params)
+	if err != nil {
+		return fmt.Errorf("Error creating Windows Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for creation of Windows Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, name, "")
+	if err != nil {
+		return fmt.Errorf("Error retrieving Windows Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("Error retrieving Windows Virtual Machine %q (Resource Group %q): `id` was nil", name, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+	return resourceArmWindowsVirtualMachineRead(d, meta)
+}
+
+func resourceArmWindowsVirtualMachineRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmClient
+	disksClient := meta.(*ArmClient).diskClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["virtualMachines"]
+
+	resp, err := client.Get(ctx, resourceGroup, name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Windows Virtual Machine %q was not found in Resource Group %q - removing from state!", name, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Windows Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.Set("name", name)
+	d.Set("resource_group_name", resourceGroup)
+	if location := resp.Location; location != nil {
+		d.Set("location", azure.NormalizeLocation(*location))
+	}
+
+	zone := ""
+	if resp.Zones != nil && len(*resp.Zones) > 0 {
+		zone = (*resp.Zones)[0]
+	}
+	d.Set("zone", zone)
+
+	if err := d.Set("identity", flattenVirtualMachineIdentity(resp.Identity)); err != nil {
+		return fmt.Errorf("Error setting `identity`: %+v", err)
+	}
+
+	props := resp.VirtualMachineProperties
+	if props == nil {
+		return fmt.Errorf("Error retrieving Windows Virtual Machine %q (Resource Group %q): `properties` was nil", name, resourceGroup)
+	}
+
+	availabilitySetId := ""
+	if props.AvailabilitySet != nil && props.AvailabilitySet.ID != nil {
+		availabilitySetId = *props.AvailabilitySet.ID
+	}
+	d.Set("availability_set_id", availabilitySetId)
+
+	if err := d.Set("boot_diagnostics", flattenVirtualMachineBootDiagnostics(props.DiagnosticsProfile)); err != nil {
+		return fmt.Errorf("Error setting `boot_diagnostics`: %+v", err)
+	}
+
+	if profile := props.HardwareProfile; profile != nil {
+		d.Set("size", string(profile.VMSize))
+	}
+
+	d.Set("license_type", props.LicenseType)
+
+	if profile := props.NetworkProfile; profile != nil {
+		if err := d.Set("network_interface_ids", flattenVirtualMachineNetworkInterfaceIDs(profile.NetworkInterfaces)); err != nil {
+			return fmt.Errorf("Error setting `network_interface_ids`: %+v", err)
+		}
+	}
+
+	if profile := props.OsProfile; profile != nil {
+		d.Set("admin_username", profile.AdminUsername)
+		d.Set("allow_extension_operations", profile.AllowExtensionOperations)
+		d.Set("computer_name", profile.ComputerName)
+
+		if err := d.Set("secret", flattenWindowsVirtualMachineSecrets(profile.Secrets)); err != nil {
+			return fmt.Errorf("Error setting `secret`: %+v", err)
+		}
+
+		if config := profile.WindowsConfiguration; config != nil {
+			d.Set("enable_automatic_updates", config.EnableAutomaticUpdates)
+			d.Set("provision_vm_agent", config.ProvisionVMAgent)
+			d.Set("timezone", config.TimeZone)
+
+			if err := d.Set("winrm_listener", flattenWindowsVirtualMachineWinRMListeners(config.WinRM)); err != nil {
+				return fmt.Errorf("Error setting `winrm_listener`: %+v", err)
+			}
+		}
+	}
+
+	if profile := props.StorageProfile; profile != nil {
+		flattenedOSDisk, err := flattenVirtualMachineOSDisk(ctx, &disksClient, profile.OsDisk)
+		if err != nil {
+			return fmt.Errorf("Error flattening `os_disk`: %+v", err)
+		}
+		if err := d.Set("os_disk", flattenedOSDisk); err != nil {
+			return fmt.Errorf("Error setting `os_disk`: %+v", err)
+		}
+
+		sourceImageId := ""
+		if profile.ImageReference != nil && profile.ImageReference.ID != nil {
+			sourceImageId = *profile.ImageReference.ID
+		}
+		d.Set("source_image_id", sourceImageId)
+
+		if err := d.Set("source_image_reference", flattenVirtualMachineSourceImageReference(profile.ImageReference)); err != nil {
+			return fmt.Errorf("Error setting `source_image_reference`: %+v", err)
+		}
+	}
+
+	d.Set("virtual_machine_id", props.VMID)
+
+	privateIPAddress := ""
+	if props.NetworkProfile != nil {
+		ipAddress, err := determineVirtualMachineIPAddress(ctx, meta, props)
+		if err != nil {
+			log.Printf("[DEBUG] Unable to determine the IP Address for Windows Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+		privateIPAddress = ipAddress
+	}
+	d.Set("private_ip_address", privateIPAddress)
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmWindowsVirtualMachineUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["virtualMachines"]
+
+	azureRMLockByName(name, virtualMachineResourceName)
+	defer azureRMUnlockByName(name, virtualMachineResourceName)
+
+	update := compute.VirtualMachineUpdate{
+		VirtualMachineProperties: &compute.VirtualMachineProperties{},
+	}
+
+	if d.HasChange("boot_diagnostics") {
+		update.VirtualMachineProperties.DiagnosticsProfile = expandVirtualMachineBootDiagnostics(d.Get("boot_diagnostics").([]interface{}))
+	}
+
+	if d.HasChange("identity") {
+		identity, err := expandVirtualMachineIdentity(d.Get("identity").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("Error expanding `identity`: %+v", err)
+		}
+		update.Identity = identity
+	}
+
+	if d.HasChange("license_type") {
+		licenseType := d.Get("license_type").(string)
+		if licenseType == "" {
+			// the API requires `None` to be sent to remove the License Type
+			licenseType = "None"
+		}
+		update.VirtualMachineProperties.LicenseType = utils.String(licenseType)
+	}
+
+	if d.HasChange("network_interface_ids") {
+		networkInterfaceIds := expandVirtualMachineNetworkInterfaceIDs(d.Get("network_interface_ids").([]interface{}))
+		update.VirtualMachineProperties.NetworkProfile = &compute.NetworkProfile{
+			NetworkInterfaces: &networkInterfaceIds,
+		}
+	}
+
+	if d.HasChange("os_disk") {
+		update.VirtualMachineProperties.StorageProfile = &compute.StorageProfile{
+			OsDisk: expandVirtualMachineOSDisk(d.Get("os_disk").([]interface{}), compute.Windows),
+		}
+	}
+
+	if d.HasChange("size") {
+		update.VirtualMachineProperties.HardwareProfile = &compute.HardwareProfile{
+			VMSize: compute.VirtualMachineSizeTypes(d.Get("size").(string)),
+		}
+	}
+
+	if d.HasChange("tags") {
+		tags := d.Get("tags").(map[string]interface{})
+		update.Tags = expandTags(tags)
+	}
+
+	// changing the NIC's or resizing the VM/OS Disk requires the VM to be deallocated
+	shouldDeallocate := virtualMachineShouldBeDeallocated(d) || d.HasChange("network_interface_ids")
+	if err := updateVirtualMachine(ctx, &client, resourceGroup, name, update, shouldDeallocate); err != nil {
+		return fmt.Errorf("Error updating Windows Virtual Machine %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	return resourceArmWindowsVirtualMachineRead(d, meta)
+}
+
+func resourceArmWindowsVirtualMachineDelete(d *schema.ResourceData, meta interface{}) error {
+	return deleteVirtualMachine(d, meta)
+}
+
+func expandWindowsVirtualMachineSecrets(input []interface{}) []compute.VaultSecretGroup {
+	output := make([]compute.VaultSecretGroup, 0)
+
+	for _, v := range input {
+		raw := v.(map[string]interface{})
+
+		certificates := make([]compute.VaultCertificate, 0)
+		for _, c := range raw["certificate"].(*schema.Set).List() {
+			certificate := c.(map[string]interface{})
+			certificates = append(certificates, compute.VaultCertificate{
+				CertificateStore: utils.String(certificate["store"].(string)),
+				CertificateURL:   utils.String(certificate["url"].(string)),
+			})
+		}
+
+		output = append(output, compute.VaultSecretGroup{
+			SourceVault: &compute.SubResource{
+				ID: utils.String(raw["key_vault_id"].(string)),
+			},
+			VaultCertificates: &certificates,
+		})
+	}
+
+	return output
+}
+
+func flattenWindowsVirtualMachineSecrets(input *[]compute.VaultSecretGroup) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make([]interface{}, 0)
+
+	for _, v := range *input {
+		keyVaultId := ""
+		if v.SourceVault != nil && v.SourceVault.ID != nil {
+			keyVaultId = *v.SourceVault.ID
+		}
+
+		certificates := make([]interface{}, 0)
+		if v.VaultCertificates != nil {
+			for _, c := range *v.VaultCertificates {
+				store := ""
+				if c.CertificateStore != nil {
+					store = *c.CertificateStore
+				}
+
+				url := ""
+				if c.CertificateURL != nil {
+					url = *c.CertificateURL
+				}
+
+				certificates = append(certificates, map[string]interface{}{
+					"store": store,
+					"url":   url,
+				})
+			}
+		}
+
+		output = append(output, map[string]interface{}{
+			"key_vault_id": keyVaultId,
+			"certificate":  certificates,
+		})
+	}
+
+	return output
+}
+
+func expandWindowsVirtualMachineWinRMListeners(input []interface{}) ([]compute.WinRMListener, error) {
+	output := make([]compute.WinRMListener, 0)
+
+	for _, v := range input {
+		raw := v.(map[string]interface{})
+
+		protocol := compute.ProtocolTypes(raw["protocol"].(string))
+		certificateUrl := raw["certificate_url"].(string)
+
+		listener := compute.WinRMListener{
+			Protocol: protocol,
+		}
+
+		if protocol == compute.HTTPS {
+			if certificateUrl == "" {
+				return nil, fmt.Errorf("A `certificate_url` must be specified when the `protocol` of a `winrm_listener` is `Https`")
+			}
+
+			listener.CertificateURL = utils.String(certificateUrl)
+		} else if certificateUrl != "" {
+			return nil, fmt.Errorf("A `certificate_url` can only be specified when the `protocol` of a `winrm_listener` is `Https`")
+		}
+
+		output = append(output, listener)
+	}
+
+	return output, nil
+}
+
+func flattenWindowsVirtualMachineWinRMListeners(input *compute.WinRMConfiguration) []interface{} {
+	if input == nil || input.Listeners == nil {
+		return []interface{}{}
+	}
+
+	output := make([]interface{}, 0)
+
+	for _, v := range *input.Listeners {
+		certificateUrl := ""
+		if v.CertificateURL != nil {
+			certificateUrl = *v.CertificateURL
+		}
+
+		output = append(output, map[string]interface{}{
+			"protocol":        string(v.Protocol),
+			"certificate_url": certificateUrl,
+		})
+	}
+
+	return output
+}
+
+func resourceArmWindowsVirtualMachineWinRMListenerHash(v interface{}) int {
+	var buf bytes.Buffer
+
+	if m, ok := v.(map[string]interface{}); ok {
+		buf.WriteString(fmt.Sprintf("%s-", strings.ToLower(m["protocol"].(string))))
+		if certificateUrl, ok := m["certificate_url"]; ok {
+			buf.WriteString(fmt.Sprintf("%s", certificateUrl.(string)))
+		}
+	}
+
+	return hashcode.String(buf.String())
+}